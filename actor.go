@@ -56,6 +56,12 @@ type LoopActor interface {
 
 // SpawnActor creates an actor and returns a message stream to it.
 func SpawnActor(a Actor) (messages ActorMessageStream, err error) {
+	return spawnActor(a, nil)
+}
+
+// spawnActor spawns the actor. If crashed is not nil, panics in OnMessage and Loop are recovered and reported to
+// crashed, after which the actor stops handling messages.
+func spawnActor(a Actor, crashed ErrorHandler) (messages ActorMessageStream, err error) {
 
 	if err = a.Init(); err != nil {
 		return
@@ -63,7 +69,7 @@ func SpawnActor(a Actor) (messages ActorMessageStream, err error) {
 
 	finalErr := NewCompleter[error]()
 	messages = newActorMessageStream(finalErr.Future())
-	messages.streamController.Stream().Listen(messageHandler(a, messages.streamController, finalErr))
+	messages.streamController.Stream().Listen(messageHandler(a, messages.streamController, finalErr, crashed))
 
 	if _, ok := a.(LoopActor); ok {
 		messages.streamController.Add(loop{})
@@ -72,15 +78,18 @@ func SpawnActor(a Actor) (messages ActorMessageStream, err error) {
 	return
 }
 
-func messageHandler(a Actor, msg *StreamController[Data], finalErr *Completer[error]) Subscriber[Data] {
+func messageHandler(a Actor, msg *StreamController[Data], finalErr *Completer[error], crashed ErrorHandler) Subscriber[Data] {
 	return func(d Data) {
+		if finalErr.Completed() {
+			return
+		}
+		if crashed != nil {
+			defer recoverCrash(finalErr, msg, crashed)
+		}
 		switch d.(type) {
 		case message:
 			a.OnMessage(d.(message).data)
 		case loop:
-			if finalErr.Completed() {
-				return
-			}
 			if a.(LoopActor).Loop() {
 				msg.Add(loop{})
 			}
@@ -94,3 +103,14 @@ func messageHandler(a Actor, msg *StreamController[Data], finalErr *Completer[er
 		}
 	}
 }
+
+// recoverCrash stops the actor with a PanicError, closes its message stream and reports it to crashed. Must be deferred
+// directly.
+func recoverCrash(finalErr *Completer[error], msg *StreamController[Data], crashed ErrorHandler) {
+	if v := recover(); v != nil {
+		err := newPanicError(v)
+		finalErr.TryComplete(err)
+		msg.Stream().Close()
+		crashed(err)
+	}
+}
@@ -0,0 +1,201 @@
+package eventual2go
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrStreamOverflow represents the error of adding an element to a full BoundedStreamController with the OverflowError
+// policy.
+var ErrStreamOverflow = errors.New("Stream overflow")
+
+// OverflowPolicy defines how a BoundedStreamController handles elements added while its queue is full.
+type OverflowPolicy int
+
+const (
+	// OverflowBlock blocks the producer until there is space in the queue.
+	OverflowBlock OverflowPolicy = iota
+	// OverflowDropNewest discards the element being added.
+	OverflowDropNewest
+	// OverflowDropOldest discards the oldest queued element to make space for the element being added.
+	OverflowDropOldest
+	// OverflowError discards the element being added and returns ErrStreamOverflow.
+	OverflowError
+)
+
+// StreamMetrics is a snapshot of the state of a BoundedStreamController.
+type StreamMetrics struct {
+	Capacity  int    // capacity of the queue
+	Queued    int    // number of elements waiting for delivery
+	MaxQueued int    // highest number of elements which were waiting for delivery at once
+	Delivered uint64 // number of elements delivered to the subscribers
+	Dropped   uint64 // number of elements discarded due to overflow
+}
+
+// A BoundedStreamController is a StreamController with a queue of limited capacity. An element is delivered to the
+// stream only after all subscribers have handled the previous one, so a slow subscriber lets the queue fill up and
+// the OverflowPolicy takes effect instead of memory growing unboundedly.
+//
+// Elements queued when the stream gets closed are still delivered, but without waiting for the subscribers. Elements
+// added afterwards are discarded.
+//
+// Backpressure only applies to subscribers registered with Listen on the stream itself, subscribers registered with
+// ListenNonBlocking and subscribers of derived streams don't hold back the delivery. Adding elements with the
+// OverflowBlock policy from within a subscriber of the same stream will deadlock, once the queue is full.
+type BoundedStreamController[T any] struct {
+	m         *sync.Mutex
+	notEmpty  *sync.Cond
+	notFull   *sync.Cond
+	sc        *StreamController[T]
+	policy    OverflowPolicy
+	capacity  int
+	queue     []queuedEvent[T]
+	closed    bool
+	maxQueued int
+	delivered uint64
+	dropped   uint64
+}
+
+// NewBoundedStreamController creates a new BoundedStreamController with the given queue capacity and OverflowPolicy.
+// A capacity lower than 1 is treated as 1.
+func NewBoundedStreamController[T any](capacity int, policy OverflowPolicy) (bsc *BoundedStreamController[T]) {
+	if capacity < 1 {
+		capacity = 1
+	}
+	m := &sync.Mutex{}
+	bsc = &BoundedStreamController[T]{
+		m:        m,
+		notEmpty: sync.NewCond(m),
+		notFull:  sync.NewCond(m),
+		sc:       NewStreamController[T](),
+		policy:   policy,
+		capacity: capacity,
+		queue:    make([]queuedEvent[T], 0, capacity),
+	}
+	bsc.sc.Stream().flush = bsc.flush
+	go bsc.deliver()
+	return
+}
+
+type queuedEvent[T any] struct {
+	data T
+	err  error
+}
+
+// Add adds an element to the stream, applying the OverflowPolicy if the queue is full. Only returns an error with the
+// OverflowError policy. Elements added after the stream got closed are discarded.
+func (bsc *BoundedStreamController[T]) Add(d T) (err error) {
+	return bsc.enqueue(queuedEvent[T]{data: d})
+}
+
+// AddError adds an error event to the stream, applying the OverflowPolicy if the queue is full. Only returns an error
+// with the OverflowError policy.
+func (bsc *BoundedStreamController[T]) AddError(err error) error {
+	return bsc.enqueue(queuedEvent[T]{err: err})
+}
+
+func (bsc *BoundedStreamController[T]) enqueue(evt queuedEvent[T]) (err error) {
+	bsc.m.Lock()
+	defer bsc.m.Unlock()
+	for !bsc.closed && len(bsc.queue) >= bsc.capacity {
+		switch bsc.policy {
+		case OverflowBlock:
+			bsc.notFull.Wait()
+			continue
+		case OverflowDropNewest:
+			bsc.dropped++
+			return
+		case OverflowDropOldest:
+			bsc.queue[0] = queuedEvent[T]{}
+			bsc.queue = bsc.queue[1:]
+			bsc.dropped++
+		case OverflowError:
+			bsc.dropped++
+			err = ErrStreamOverflow
+			return
+		}
+	}
+	if bsc.closed {
+		return
+	}
+	bsc.queue = append(bsc.queue, evt)
+	if len(bsc.queue) > bsc.maxQueued {
+		bsc.maxQueued = len(bsc.queue)
+	}
+	bsc.notEmpty.Signal()
+	return
+}
+
+// Join joins a stream. All elements and error events from the source will be added to the stream, overflow errors are
+// discarded.
+func (bsc *BoundedStreamController[T]) Join(source *Stream[T]) {
+	sub := source.ListenWithError(bsc.join, bsc.joinError)
+	sub.CancelOnFuture(bsc.sc.Stream().Closed())
+}
+
+func (bsc *BoundedStreamController[T]) join(d T) {
+	bsc.Add(d)
+}
+
+func (bsc *BoundedStreamController[T]) joinError(err error) {
+	bsc.AddError(err)
+}
+
+// Stream return the underlying stream.
+func (bsc *BoundedStreamController[T]) Stream() *Stream[T] {
+	return bsc.sc.Stream()
+}
+
+// Metrics returns a snapshot of the current queue state.
+func (bsc *BoundedStreamController[T]) Metrics() (m StreamMetrics) {
+	bsc.m.Lock()
+	defer bsc.m.Unlock()
+	m = StreamMetrics{
+		Capacity:  bsc.capacity,
+		Queued:    len(bsc.queue),
+		MaxQueued: bsc.maxQueued,
+		Delivered: bsc.delivered,
+		Dropped:   bsc.dropped,
+	}
+	return
+}
+
+func (bsc *BoundedStreamController[T]) deliver() {
+	for {
+		bsc.m.Lock()
+		for !bsc.closed && len(bsc.queue) == 0 {
+			bsc.notEmpty.Wait()
+		}
+		if bsc.closed {
+			bsc.m.Unlock()
+			return
+		}
+		evt := bsc.queue[0]
+		bsc.queue[0] = queuedEvent[T]{}
+		bsc.queue = bsc.queue[1:]
+		bsc.notFull.Signal()
+		// adding under the lock keeps the order with elements flushed on close
+		ack := bsc.sc.addAcked(evt.data, evt.err)
+		bsc.m.Unlock()
+
+		ack.Wait()
+
+		bsc.m.Lock()
+		bsc.delivered++
+		bsc.m.Unlock()
+	}
+}
+
+// flush adds all queued elements to the stream without waiting for the subscribers, before the stream closes.
+func (bsc *BoundedStreamController[T]) flush() {
+	bsc.m.Lock()
+	defer bsc.m.Unlock()
+	bsc.closed = true
+	for _, evt := range bsc.queue {
+		bsc.sc.add(evt.data, evt.err)
+		bsc.delivered++
+	}
+	bsc.queue = nil
+	bsc.notEmpty.Broadcast()
+	bsc.notFull.Broadcast()
+}
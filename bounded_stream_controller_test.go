@@ -0,0 +1,232 @@
+package eventual2go
+
+import (
+	"testing"
+	"time"
+)
+
+// blockingSubscriber reports every element on received and blocks until it is released.
+func blockingSubscriber(received chan int, release chan struct{}) Subscriber[int] {
+	return func(d int) {
+		received <- d
+		<-release
+	}
+}
+
+// waitDelivered waits for the acknowledgement of the element in delivery, which is counted after the subscribers
+// returned.
+func waitDelivered(bsc *BoundedStreamController[int], n uint64) (m StreamMetrics) {
+	timeout := time.After(10 * time.Millisecond)
+	for m = bsc.Metrics(); m.Delivered < n; m = bsc.Metrics() {
+		select {
+		case <-timeout:
+			return
+		case <-time.After(100 * time.Microsecond):
+		}
+	}
+	return
+}
+
+func TestBoundedStreamBlock(t *testing.T) {
+	bsc := NewBoundedStreamController[int](1, OverflowBlock)
+	received := make(chan int, 10)
+	release := make(chan struct{})
+	bsc.Stream().Listen(blockingSubscriber(received, release))
+
+	bsc.Add(1)
+	if <-received != 1 {
+		t.Fatal("got wrong data")
+	}
+	bsc.Add(2)
+
+	added := make(chan struct{})
+	go func() {
+		bsc.Add(3)
+		close(added)
+	}()
+
+	select {
+	case <-added:
+		t.Fatal("producer didn't block")
+	case <-time.After(5 * time.Millisecond):
+	}
+	if m := bsc.Metrics(); m.Queued != 1 || m.Delivered != 0 {
+		t.Error("wrong metrics", m)
+	}
+
+	release <- struct{}{}
+	select {
+	case <-added:
+	case <-time.After(10 * time.Millisecond):
+		t.Fatal("producer didn't unblock")
+	}
+
+	for i := 2; i <= 3; i++ {
+		if d := <-received; d != i {
+			t.Error("got wrong data", d)
+		}
+		release <- struct{}{}
+	}
+	time.Sleep(1 * time.Millisecond)
+	if m := bsc.Metrics(); m.Queued != 0 || m.Delivered != 3 || m.MaxQueued != 1 || m.Dropped != 0 {
+		t.Error("wrong metrics", m)
+	}
+}
+
+func TestBoundedStreamDropNewest(t *testing.T) {
+	bsc := NewBoundedStreamController[int](2, OverflowDropNewest)
+	received := make(chan int, 10)
+	release := make(chan struct{})
+	bsc.Stream().Listen(blockingSubscriber(received, release))
+
+	bsc.Add(1)
+	<-received
+	for i := 2; i <= 5; i++ {
+		if err := bsc.Add(i); err != nil {
+			t.Error("got error", err)
+		}
+	}
+	close(release)
+
+	for i := 2; i <= 3; i++ {
+		if d := <-received; d != i {
+			t.Error("got wrong data", d)
+		}
+	}
+	time.Sleep(1 * time.Millisecond)
+	if len(received) != 0 {
+		t.Error("got dropped data")
+	}
+	if m := bsc.Metrics(); m.Dropped != 2 || m.Delivered != 3 {
+		t.Error("wrong metrics", m)
+	}
+}
+
+func TestBoundedStreamDropOldest(t *testing.T) {
+	bsc := NewBoundedStreamController[int](2, OverflowDropOldest)
+	received := make(chan int, 10)
+	release := make(chan struct{})
+	bsc.Stream().Listen(blockingSubscriber(received, release))
+
+	bsc.Add(1)
+	<-received
+	for i := 2; i <= 5; i++ {
+		bsc.Add(i)
+	}
+	close(release)
+
+	for i := 4; i <= 5; i++ {
+		if d := <-received; d != i {
+			t.Error("got wrong data", d)
+		}
+	}
+	if m := bsc.Metrics(); m.Dropped != 2 {
+		t.Error("wrong metrics", m)
+	}
+}
+
+func TestBoundedStreamError(t *testing.T) {
+	bsc := NewBoundedStreamController[int](1, OverflowError)
+	received := make(chan int, 10)
+	release := make(chan struct{})
+	defer close(release)
+	bsc.Stream().Listen(blockingSubscriber(received, release))
+
+	bsc.Add(1)
+	<-received
+	if err := bsc.Add(2); err != nil {
+		t.Error("got error", err)
+	}
+	if err := bsc.Add(3); err != ErrStreamOverflow {
+		t.Error("got wrong error", err)
+	}
+}
+
+func TestBoundedStreamClose(t *testing.T) {
+	bsc := NewBoundedStreamController[int](1, OverflowBlock)
+	received := make(chan int, 10)
+	release := make(chan struct{})
+	sub := bsc.Stream().Listen(blockingSubscriber(received, release))
+
+	bsc.Add(1)
+	<-received
+	bsc.Add(2)
+
+	added := make(chan struct{})
+	go func() {
+		bsc.Add(3)
+		close(added)
+	}()
+	bsc.Stream().Close()
+
+	select {
+	case <-added:
+	case <-time.After(10 * time.Millisecond):
+		t.Fatal("producer didn't unblock")
+	}
+
+	close(release)
+	if d := <-received; d != 2 {
+		t.Error("got wrong data", d)
+	}
+	if !sub.Done().WaitUntilTimeout(10 * time.Millisecond) {
+		t.Fatal("subscription didn't end")
+	}
+	select {
+	case d := <-received:
+		t.Error("got unexpected data", d)
+	default:
+	}
+	if m := waitDelivered(bsc, 2); m.Delivered != 2 || m.Dropped != 0 || m.Queued != 0 {
+		t.Error("wrong metrics", m)
+	}
+}
+
+func TestBoundedStreamCloseQueued(t *testing.T) {
+	bsc := NewBoundedStreamController[int](10, OverflowBlock)
+	received := make(chan int, 10)
+	release := make(chan struct{})
+	sub := bsc.Stream().Listen(blockingSubscriber(received, release))
+
+	for i := 1; i <= 5; i++ {
+		bsc.Add(i)
+	}
+	bsc.Stream().Close()
+	close(release)
+
+	for i := 1; i <= 5; i++ {
+		select {
+		case d := <-received:
+			if d != i {
+				t.Error("got wrong data", d)
+			}
+		case <-time.After(10 * time.Millisecond):
+			t.Fatal("no response")
+		}
+	}
+	if !sub.Done().WaitUntilTimeout(10 * time.Millisecond) {
+		t.Fatal("subscription didn't end")
+	}
+	if m := waitDelivered(bsc, 5); m.Delivered != 5 || m.Dropped != 0 {
+		t.Error("wrong metrics", m)
+	}
+}
+
+func TestBoundedStreamAddError(t *testing.T) {
+	bsc := NewBoundedStreamController[int](1, OverflowBlock)
+	errs := make(chan error, 1)
+	bsc.Stream().OnError(func(err error) {
+		errs <- err
+	})
+
+	bsc.AddError(ErrTimeout)
+
+	select {
+	case err := <-errs:
+		if err != ErrTimeout {
+			t.Error("got wrong error", err)
+		}
+	case <-time.After(10 * time.Millisecond):
+		t.Fatal("no response")
+	}
+}
@@ -0,0 +1,228 @@
+package eventual2go
+
+import (
+	"sort"
+	"sync"
+	"time"
+)
+
+// Clock is the source of time for all time-dependent functions. Use a ManualClock to control time in tests.
+type Clock interface {
+	// Now returns the current time.
+	Now() time.Time
+	// After waits for the duration to elapse and then sends the current time on the returned channel.
+	After(d time.Duration) <-chan time.Time
+	// Sleep blocks for the given duration.
+	Sleep(d time.Duration)
+	// NewTimer creates a new ClockTimer that will send the current time on its channel after the given duration.
+	NewTimer(d time.Duration) ClockTimer
+	// AfterFunc waits for the duration to elapse and then calls f in its own go-routine. The channel of the returned
+	// ClockTimer is not used.
+	AfterFunc(d time.Duration, f func()) ClockTimer
+}
+
+// ClockTimer is a single event timer created by a Clock.
+type ClockTimer interface {
+	// C returns the channel on which the time is delivered.
+	C() <-chan time.Time
+	// Stop prevents the timer from firing. Returns false if the timer has already expired or been stopped.
+	Stop() bool
+	// Reset changes the timer to expire after the given duration. Returns true if the timer had been active.
+	Reset(d time.Duration) bool
+}
+
+// RealClock is a Clock backed by the system time.
+type RealClock struct{}
+
+// Now returns the current system time.
+func (RealClock) Now() time.Time {
+	return time.Now()
+}
+
+// After is the same as time.After.
+func (RealClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// Sleep is the same as time.Sleep.
+func (RealClock) Sleep(d time.Duration) {
+	time.Sleep(d)
+}
+
+// NewTimer creates a new ClockTimer backed by a time.Timer.
+func (RealClock) NewTimer(d time.Duration) ClockTimer {
+	return realTimer{time.NewTimer(d)}
+}
+
+// AfterFunc is the same as time.AfterFunc.
+func (RealClock) AfterFunc(d time.Duration, f func()) ClockTimer {
+	return realTimer{time.AfterFunc(d, f)}
+}
+
+type realTimer struct {
+	t *time.Timer
+}
+
+func (rt realTimer) C() <-chan time.Time {
+	return rt.t.C
+}
+
+func (rt realTimer) Stop() bool {
+	return rt.t.Stop()
+}
+
+func (rt realTimer) Reset(d time.Duration) bool {
+	return rt.t.Reset(d)
+}
+
+// ManualClock is a Clock which time only changes when advanced manually. Timers fire when the clock is advanced
+// beyond their deadline.
+type ManualClock struct {
+	m       *sync.Mutex
+	changed *sync.Cond
+	now     time.Time
+	timers  []*manualTimer
+}
+
+// NewManualClock creates a new ManualClock set to the given time.
+func NewManualClock(now time.Time) (mc *ManualClock) {
+	m := &sync.Mutex{}
+	mc = &ManualClock{
+		m:       m,
+		changed: sync.NewCond(m),
+		now:     now,
+	}
+	return
+}
+
+// Now returns the current time of the clock.
+func (mc *ManualClock) Now() time.Time {
+	mc.m.Lock()
+	defer mc.m.Unlock()
+	return mc.now
+}
+
+// After waits for the clock to be advanced by the given duration and then sends the current time on the returned
+// channel.
+func (mc *ManualClock) After(d time.Duration) <-chan time.Time {
+	return mc.NewTimer(d).C()
+}
+
+// Sleep blocks until the clock has been advanced by the given duration.
+func (mc *ManualClock) Sleep(d time.Duration) {
+	<-mc.After(d)
+}
+
+// NewTimer creates a new ClockTimer which fires when the clock has been advanced by the given duration.
+func (mc *ManualClock) NewTimer(d time.Duration) ClockTimer {
+	t := &manualTimer{
+		mc: mc,
+		c:  make(chan time.Time, 1),
+	}
+	t.Reset(d)
+	return t
+}
+
+// AfterFunc calls f in its own go-routine, when the clock has been advanced by the given duration.
+func (mc *ManualClock) AfterFunc(d time.Duration, f func()) ClockTimer {
+	t := &manualTimer{
+		mc: mc,
+		f:  f,
+	}
+	t.Reset(d)
+	return t
+}
+
+// Advance moves the clock forward by the given duration, firing all timers which deadline is reached in the order of
+// their deadlines.
+func (mc *ManualClock) Advance(d time.Duration) {
+	mc.m.Lock()
+	defer mc.m.Unlock()
+	end := mc.now.Add(d)
+	for len(mc.timers) != 0 && !mc.timers[0].deadline.After(end) {
+		t := mc.timers[0]
+		mc.timers = mc.timers[1:]
+		mc.now = t.deadline
+		t.fire(mc.now)
+	}
+	mc.now = end
+	mc.changed.Broadcast()
+}
+
+// BlockUntil blocks until at least n timers are waiting on the clock. Use it to ensure that goroutines have reached a
+// call to Sleep or After before advancing the clock.
+func (mc *ManualClock) BlockUntil(n int) {
+	mc.m.Lock()
+	defer mc.m.Unlock()
+	for len(mc.timers) < n {
+		mc.changed.Wait()
+	}
+}
+
+// Waiters returns the number of timers waiting on the clock.
+func (mc *ManualClock) Waiters() int {
+	mc.m.Lock()
+	defer mc.m.Unlock()
+	return len(mc.timers)
+}
+
+// must be called with lock held.
+func (mc *ManualClock) schedule(t *manualTimer) {
+	mc.timers = append(mc.timers, t)
+	sort.SliceStable(mc.timers, func(i, j int) bool {
+		return mc.timers[i].deadline.Before(mc.timers[j].deadline)
+	})
+	mc.changed.Broadcast()
+}
+
+// must be called with lock held.
+func (mc *ManualClock) unschedule(t *manualTimer) (active bool) {
+	for i, tt := range mc.timers {
+		if tt == t {
+			mc.timers = append(mc.timers[:i], mc.timers[i+1:]...)
+			return true
+		}
+	}
+	return
+}
+
+type manualTimer struct {
+	mc       *ManualClock
+	c        chan time.Time
+	f        func() // set for timers created with AfterFunc
+	deadline time.Time
+}
+
+func (t *manualTimer) C() <-chan time.Time {
+	return t.c
+}
+
+func (t *manualTimer) Stop() bool {
+	t.mc.m.Lock()
+	defer t.mc.m.Unlock()
+	return t.mc.unschedule(t)
+}
+
+func (t *manualTimer) Reset(d time.Duration) (active bool) {
+	t.mc.m.Lock()
+	defer t.mc.m.Unlock()
+	active = t.mc.unschedule(t)
+	t.deadline = t.mc.now.Add(d)
+	if d <= 0 {
+		t.fire(t.mc.now)
+		return
+	}
+	t.mc.schedule(t)
+	return
+}
+
+func (t *manualTimer) fire(now time.Time) {
+	if t.f != nil {
+		go t.f()
+		return
+	}
+	select {
+	case t.c <- now:
+	default:
+	}
+}
@@ -0,0 +1,110 @@
+package eventual2go
+
+import (
+	"testing"
+	"time"
+)
+
+func TestManualClockAdvance(t *testing.T) {
+	start := time.Unix(0, 0)
+	mc := NewManualClock(start)
+
+	t1 := mc.After(2 * time.Second)
+	t2 := mc.After(1 * time.Second)
+
+	mc.Advance(500 * time.Millisecond)
+	select {
+	case <-t1:
+		t.Error("timer fired to early")
+	case <-t2:
+		t.Error("timer fired to early")
+	default:
+	}
+
+	mc.Advance(2 * time.Second)
+	if now := <-t2; !now.Equal(start.Add(1 * time.Second)) {
+		t.Error("timer fired with wrong time", now)
+	}
+	if now := <-t1; !now.Equal(start.Add(2 * time.Second)) {
+		t.Error("timer fired with wrong time", now)
+	}
+	if !mc.Now().Equal(start.Add(2500 * time.Millisecond)) {
+		t.Error("wrong time", mc.Now())
+	}
+}
+
+func TestManualClockSleep(t *testing.T) {
+	mc := NewManualClock(time.Unix(0, 0))
+	done := make(chan struct{})
+	go func() {
+		mc.Sleep(1 * time.Second)
+		close(done)
+	}()
+
+	mc.BlockUntil(1)
+	mc.Advance(1 * time.Second)
+
+	select {
+	case <-done:
+	case <-time.After(10 * time.Millisecond):
+		t.Error("sleep didn't return")
+	}
+}
+
+func TestManualClockTimer(t *testing.T) {
+	mc := NewManualClock(time.Unix(0, 0))
+	tm := mc.NewTimer(1 * time.Second)
+
+	if !tm.Stop() {
+		t.Error("stopping active timer returned false")
+	}
+	mc.Advance(1 * time.Second)
+	select {
+	case <-tm.C():
+		t.Error("stopped timer fired")
+	default:
+	}
+
+	if tm.Reset(1 * time.Second) {
+		t.Error("resetting stopped timer returned true")
+	}
+	if mc.Waiters() != 1 {
+		t.Error("wrong number of waiters", mc.Waiters())
+	}
+	mc.Advance(1 * time.Second)
+	select {
+	case <-tm.C():
+	default:
+		t.Error("reset timer didn't fire")
+	}
+}
+
+func TestManualClockAfterFunc(t *testing.T) {
+	mc := NewManualClock(time.Unix(0, 0))
+	fired := make(chan struct{}, 1)
+	tm := mc.AfterFunc(1*time.Second, func() {
+		fired <- struct{}{}
+	})
+
+	mc.Advance(999 * time.Millisecond)
+	select {
+	case <-fired:
+		t.Error("fired too early")
+	case <-time.After(1 * time.Millisecond):
+	}
+	mc.Advance(1 * time.Millisecond)
+	select {
+	case <-fired:
+	case <-time.After(10 * time.Millisecond):
+		t.Fatal("didn't fire")
+	}
+
+	tm.Reset(1 * time.Second)
+	tm.Stop()
+	mc.Advance(1 * time.Second)
+	select {
+	case <-fired:
+		t.Error("stopped timer fired")
+	case <-time.After(1 * time.Millisecond):
+	}
+}
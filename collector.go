@@ -41,7 +41,7 @@ func (c *Collector[T]) Add(d T) {
 
 // AddStream collects all events on `Stream`
 func (c *Collector[T]) AddStream(s *Stream[T]) {
-	s.Listen(c.Add).CompleteOnFuture(c.remove.Future())
+	s.Listen(c.Add).CancelOnFuture(c.remove.Future())
 }
 
 // AddObservable collects all changes off an `Observable`
@@ -1,6 +1,7 @@
 package eventual2go
 
 import (
+	"context"
 	"errors"
 	"time"
 )
@@ -20,11 +21,39 @@ func NewCompleter[T any]() (c *Completer[T]) {
 	return
 }
 
+// NewCompleterWithDispatch creates a new Completer, which Future invokes its handlers with the given DispatchMode. Use
+// NewCompleterWithExecutor for DispatchExecutor.
+func NewCompleterWithDispatch[T any](mode DispatchMode) (c *Completer[T]) {
+	c = &Completer[T]{newFutureWithDispatch[T](mode, nil)}
+	return
+}
+
+// NewCompleterWithExecutor creates a new Completer, which Future passes its handlers to the given Executor.
+func NewCompleterWithExecutor[T any](e Executor) (c *Completer[T]) {
+	c = &Completer[T]{newFutureWithDispatch[T](DispatchExecutor, e)}
+	return
+}
+
 // NewTimeoutCompleter creates a new Completer, which error completes after the specified duration, if Completer hasnt been completed otherwise.
 func NewTimeoutCompleter[T any](d time.Duration) (c *Completer[T]) {
+	return NewTimeoutCompleterWithClock[T](d, RealClock{})
+}
+
+// NewTimeoutCompleterWithClock is the same as NewTimeoutCompleter, but measures the duration with the given Clock.
+func NewTimeoutCompleterWithClock[T any](d time.Duration, clock Clock) (c *Completer[T]) {
 	c = &Completer[T]{newFuture[T]()}
 
-	go timeout(c, d)
+	go timeout(c, d, clock)
+
+	return
+}
+
+// NewCompleterWithContext creates a new Completer, which error completes with the error of the context, if the context
+// is done before the Completer has been completed otherwise.
+func NewCompleterWithContext[T any](ctx context.Context) (c *Completer[T]) {
+	c = &Completer[T]{newFuture[T]()}
+
+	go errorOnDone(c, ctx)
 
 	return
 }
@@ -34,16 +63,26 @@ func (c *Completer[T]) Complete(d T) {
 	c.f.complete(d)
 }
 
-// CompleteOn invokes a CompletionFunc in a go-routine and either completes with the resut or the error if it is not nil. Don't invoke this function more then once to avoid multiple complition panics.
+// TryComplete is the same as Complete, but returns false instead of panicking if the Completer is already complete.
+func (c *Completer[T]) TryComplete(d T) (ok bool) {
+	return c.f.tryComplete(d)
+}
+
+// CompleteOn invokes a CompletionFunc in a go-routine, or with the Executor of the Completer if any, and either completes with the resut or the error if it is not nil. If the Completer is already complete when the function returns, e.g. by a timeout, the result is dropped.
 func (c *Completer[T]) CompleteOn(f CompletionFunc[T]) {
 	handler := func(f CompletionFunc[T]) {
+		defer failOnPanic(c)
 		d, err := f()
 		if err == nil {
-			c.Complete(d)
+			c.TryComplete(d)
 		} else {
-			c.CompleteError(err)
+			c.TryCompleteError(err)
 		}
 	}
+	if c.f.executor != nil {
+		c.f.executor.Execute(func() { handler(f) })
+		return
+	}
 	go handler(f)
 }
 
@@ -62,6 +101,12 @@ func (c *Completer[T]) CompleteError(err error) {
 	c.f.completeError(err)
 }
 
+// TryCompleteError is the same as CompleteError, but returns false instead of panicking if the Completer is already
+// complete.
+func (c *Completer[T]) TryCompleteError(err error) (ok bool) {
+	return c.f.tryCompleteError(err)
+}
+
 // CompleteOnFuture completes the completer with the result or the error of a `Future`.
 func (c *Completer[T]) CompleteOnFuture(f *Future[T]) {
 	f.Then(completeFuture(c))
@@ -80,9 +125,40 @@ func completeFutureError[T any](c *Completer[T]) ErrorHandler {
 	}
 }
 
-func timeout[T any](c *Completer[T], d time.Duration) {
-	time.Sleep(d)
-	if !c.Completed() {
-		c.CompleteError(ErrTimeout)
+func tryCompleteFuture[T any](c *Completer[T]) CompletionHandler[T] {
+	return func(d T) {
+		c.TryComplete(d)
+	}
+}
+
+func tryCompleteFutureError[T any](c *Completer[T]) ErrorHandler {
+	return func(err error) {
+		c.TryCompleteError(err)
+	}
+}
+
+func errorOnDone[T any](c *Completer[T], ctx context.Context) {
+	select {
+	case <-ctx.Done():
+		c.TryCompleteError(ctx.Err())
+	case <-c.f.AsErrChan():
+	}
+}
+
+func completeOnContext[T any](c *Completer[T], ctx context.Context, d T) {
+	select {
+	case <-ctx.Done():
+		c.TryComplete(d)
+	case <-c.f.AsErrChan():
+	}
+}
+
+func timeout[T any](c *Completer[T], d time.Duration, clock Clock) {
+	t := clock.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C():
+		c.TryCompleteError(ErrTimeout)
+	case <-c.f.AsErrChan():
 	}
 }
@@ -0,0 +1,85 @@
+package eventual2go
+
+// DispatchMode defines how a Future invokes its completion and error handlers.
+type DispatchMode int
+
+const (
+	// DispatchDefault invokes every completion handler in a new go-routine and error handlers synchronously.
+	DispatchDefault DispatchMode = iota
+	// DispatchSync invokes all handlers synchronously in the order of their registration, in the go-routine completing
+	// the Future or, if it is already complete, registering the handler.
+	DispatchSync
+	// DispatchAsync invokes every handler in a new go-routine.
+	DispatchAsync
+	// DispatchExecutor passes every handler in the order of their registration to an Executor.
+	DispatchExecutor
+)
+
+// dispatchCompletion invokes a completion handler of the Future according to its DispatchMode.
+func (f *Future[T]) dispatchCompletion(ch CompletionHandler[T], d T) {
+	switch f.mode {
+	case DispatchSync:
+		runCompletionHandler(ch, d)
+	case DispatchExecutor:
+		f.executor.Execute(func() { runCompletionHandler(ch, d) })
+	default:
+		go runCompletionHandler(ch, d)
+	}
+}
+
+// dispatchError invokes an error handler of the Future according to its DispatchMode.
+func (f *Future[T]) dispatchError(eh ErrorHandler, err error) {
+	switch f.mode {
+	case DispatchAsync:
+		go runErrorHandler(eh, err)
+	case DispatchExecutor:
+		f.executor.Execute(func() { runErrorHandler(eh, err) })
+	default:
+		runErrorHandler(eh, err)
+	}
+}
+
+// enqueueCompletion queues the dispatch of a completion handler. Must be called with lock held.
+func (f *Future[T]) enqueueCompletion(ch CompletionHandler[T], d T) {
+	f.queue = append(f.queue, func() { f.dispatchCompletion(ch, d) })
+}
+
+// enqueueError queues the dispatch of an error handler. Must be called with lock held.
+func (f *Future[T]) enqueueError(eh ErrorHandler, err error) {
+	f.queue = append(f.queue, func() { f.dispatchError(eh, err) })
+}
+
+// dispatchInOrder runs the queued dispatches in order, unless another go-routine already does. This way handlers
+// registered while the Future is completing can't overtake handlers registered before. Must be called with lock held
+// and releases it.
+func (f *Future[T]) dispatchInOrder() {
+	if f.running {
+		f.m.Unlock()
+		return
+	}
+	f.running = true
+	for len(f.queue) != 0 {
+		dispatch := f.queue[0]
+		f.queue = f.queue[1:]
+		f.m.Unlock()
+		f.runDispatch(dispatch)
+		f.m.Lock()
+	}
+	f.running = false
+	f.queue = nil
+	f.m.Unlock()
+}
+
+// runDispatch runs a queued dispatch. If it panics, the queue is released, so later registrations dispatch the rest.
+func (f *Future[T]) runDispatch(dispatch func()) {
+	done := false
+	defer func() {
+		if !done {
+			f.m.Lock()
+			f.running = false
+			f.m.Unlock()
+		}
+	}()
+	dispatch()
+	done = true
+}
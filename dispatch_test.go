@@ -0,0 +1,124 @@
+package eventual2go
+
+import (
+	"testing"
+	"time"
+)
+
+// queueExecutor collects functions until they are run manually.
+type queueExecutor struct {
+	fs []func()
+}
+
+func (qe *queueExecutor) Execute(f func()) {
+	qe.fs = append(qe.fs, f)
+}
+
+func (qe *queueExecutor) run() {
+	for len(qe.fs) != 0 {
+		f := qe.fs[0]
+		qe.fs = qe.fs[1:]
+		f()
+	}
+}
+
+func TestDispatchSync(t *testing.T) {
+	c := NewCompleterWithDispatch[int](DispatchSync)
+	var order []int
+	for i := 0; i < 3; i++ {
+		c.Future().Then(appendTo(&order, i))
+	}
+	c.Complete(0)
+	if len(order) != 3 || order[0] != 0 || order[1] != 1 || order[2] != 2 {
+		t.Error("wrong order", order)
+	}
+
+	c.Future().Then(appendTo(&order, 3))
+	if len(order) != 4 {
+		t.Error("handler wasn't invoked immediately")
+	}
+}
+
+func TestDispatchSyncError(t *testing.T) {
+	c := NewCompleterWithDispatch[int](DispatchSync)
+	var order []int
+	for i := 0; i < 3; i++ {
+		i := i
+		c.Future().Err(func(error) {
+			order = append(order, i)
+		})
+	}
+	c.CompleteError(ErrTimeout)
+	if len(order) != 3 || order[0] != 0 || order[1] != 1 || order[2] != 2 {
+		t.Error("wrong order", order)
+	}
+}
+
+func TestDispatchSyncRegisterWhileCompleting(t *testing.T) {
+	c := NewCompleterWithDispatch[int](DispatchSync)
+	var order []int
+	registered := make(chan struct{})
+	c.Future().Then(func(int) {
+		order = append(order, 0)
+		go func() {
+			c.Future().Then(appendTo(&order, 2))
+			close(registered)
+		}()
+		<-registered
+		order = append(order, 1)
+	})
+	c.Complete(0)
+	if len(order) != 3 || order[0] != 0 || order[1] != 1 || order[2] != 2 {
+		t.Error("wrong order", order)
+	}
+}
+
+func appendTo(order *[]int, i int) CompletionHandler[int] {
+	return func(int) {
+		*order = append(*order, i)
+	}
+}
+
+func TestDispatchAsyncError(t *testing.T) {
+	c := NewCompleterWithDispatch[int](DispatchAsync)
+	release := make(chan struct{})
+	handled := make(chan struct{})
+	c.Future().Err(func(error) {
+		<-release
+		close(handled)
+	})
+
+	c.CompleteError(ErrTimeout)
+	close(release)
+	select {
+	case <-handled:
+	case <-time.After(10 * time.Millisecond):
+		t.Fatal("error handler wasn't invoked")
+	}
+}
+
+func TestDispatchExecutor(t *testing.T) {
+	qe := &queueExecutor{}
+	c := NewCompleterWithExecutor[int](qe)
+	if c.Future().Dispatch() != DispatchExecutor {
+		t.Error("wrong dispatch mode")
+	}
+	var order []int
+	for i := 0; i < 3; i++ {
+		c.Future().Then(appendTo(&order, i))
+	}
+	mf := MapFuture(c.Future(), func(d int) int { return d + 1 })
+	mf.Then(appendTo(&order, 3))
+
+	c.Complete(0)
+	if len(order) != 0 {
+		t.Error("handlers weren't passed to the executor")
+	}
+	qe.run()
+	if len(order) != 4 || order[0] != 0 || order[1] != 1 || order[2] != 2 || order[3] != 3 {
+		t.Error("wrong order", order)
+	}
+	if mf.Dispatch() != DispatchExecutor {
+		t.Error("derived future has wrong dispatch mode")
+	}
+}
@@ -0,0 +1,97 @@
+package eventual2go
+
+import "sync"
+
+// An Executor runs functions, e.g. the handlers of a Future with the DispatchExecutor mode.
+type Executor interface {
+	// Execute runs the function. Functions passed in order by a single go-routine should be run in that order, if the
+	// Executor has a single worker.
+	Execute(f func())
+}
+
+// InlineExecutor is an Executor, which runs functions synchronously in the calling go-routine.
+type InlineExecutor struct{}
+
+// Execute runs the function immediately.
+func (InlineExecutor) Execute(f func()) {
+	f()
+}
+
+// WorkerPool is an Executor, which runs functions on a fixed number of worker go-routines. Functions passed while all
+// workers are busy are queued in FIFO order, so Execute never blocks. A function waiting for another function of the
+// same WorkerPool to run will deadlock, if all workers are busy.
+type WorkerPool struct {
+	m       *sync.Mutex
+	pending *sync.Cond
+	queue   []func()
+	stopped bool
+}
+
+// NewWorkerPool creates a new WorkerPool with the given number of workers. A number lower than 1 is treated as 1.
+func NewWorkerPool(workers int) (wp *WorkerPool) {
+	if workers < 1 {
+		workers = 1
+	}
+	m := &sync.Mutex{}
+	wp = &WorkerPool{
+		m:       m,
+		pending: sync.NewCond(m),
+	}
+	for i := 0; i < workers; i++ {
+		go wp.work()
+	}
+	return
+}
+
+// Execute queues the function to be run by the next idle worker. Functions passed after Shutdown are run in a new
+// go-routine.
+func (wp *WorkerPool) Execute(f func()) {
+	wp.m.Lock()
+	defer wp.m.Unlock()
+	if wp.stopped {
+		go wp.run(f)
+		return
+	}
+	wp.queue = append(wp.queue, f)
+	wp.pending.Signal()
+}
+
+// Queued returns the number of functions waiting for a worker.
+func (wp *WorkerPool) Queued() int {
+	wp.m.Lock()
+	defer wp.m.Unlock()
+	return len(wp.queue)
+}
+
+// Shutdown stops the workers after all queued functions have been run. The error is to fullfill the `Shutdowner`
+// interface and will always be nil.
+func (wp *WorkerPool) Shutdown(Data) error {
+	wp.m.Lock()
+	defer wp.m.Unlock()
+	wp.stopped = true
+	wp.pending.Broadcast()
+	return nil
+}
+
+func (wp *WorkerPool) work() {
+	for {
+		wp.m.Lock()
+		for !wp.stopped && len(wp.queue) == 0 {
+			wp.pending.Wait()
+		}
+		if len(wp.queue) == 0 {
+			wp.m.Unlock()
+			return
+		}
+		f := wp.queue[0]
+		wp.queue[0] = nil
+		wp.queue = wp.queue[1:]
+		wp.m.Unlock()
+		wp.run(f)
+	}
+}
+
+func (wp *WorkerPool) run(f func()) {
+	defer recoverPanic(nil)
+	f()
+}
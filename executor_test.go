@@ -0,0 +1,215 @@
+package eventual2go
+
+import (
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestWorkerPoolConcurrency(t *testing.T) {
+	wp := NewWorkerPool(2)
+	defer wp.Shutdown(nil)
+	var running, max int32
+	wg := &sync.WaitGroup{}
+	wg.Add(10)
+	for i := 0; i < 10; i++ {
+		wp.Execute(func() {
+			defer wg.Done()
+			n := atomic.AddInt32(&running, 1)
+			for {
+				m := atomic.LoadInt32(&max)
+				if n <= m || atomic.CompareAndSwapInt32(&max, m, n) {
+					break
+				}
+			}
+			time.Sleep(1 * time.Millisecond)
+			atomic.AddInt32(&running, -1)
+		})
+	}
+	wg.Wait()
+	if max != 2 {
+		t.Error("wrong concurrency", max)
+	}
+}
+
+func TestWorkerPoolShutdown(t *testing.T) {
+	wp := NewWorkerPool(1)
+	release := make(chan struct{})
+	started := make(chan struct{})
+	done := make(chan int, 3)
+	wp.Execute(func() {
+		close(started)
+		<-release
+		done <- 1
+	})
+	wp.Execute(func() {
+		done <- 2
+	})
+	<-started
+	if wp.Queued() != 1 {
+		t.Error("wrong queue size", wp.Queued())
+	}
+	wp.Shutdown(nil)
+	wp.Execute(func() {
+		done <- 3
+	})
+	if <-done != 3 {
+		t.Error("function after shutdown wasn't run")
+	}
+	close(release)
+	for i := 1; i <= 2; i++ {
+		select {
+		case d := <-done:
+			if d != i {
+				t.Error("wrong order", d)
+			}
+		case <-time.After(10 * time.Millisecond):
+			t.Fatal("queued function wasn't run")
+		}
+	}
+}
+
+func TestInlineExecutor(t *testing.T) {
+	c := NewCompleterWithExecutor[int](InlineExecutor{})
+	res := 0
+	c.Future().Then(func(d int) {
+		res = d
+	})
+	c.Complete(1)
+	if res != 1 {
+		t.Error("handler wasn't invoked synchronously")
+	}
+}
+
+func TestStreamControllerWithExecutor(t *testing.T) {
+	wp := NewWorkerPool(1)
+	defer wp.Shutdown(nil)
+	sc := NewStreamControllerWithExecutor[int](wp)
+	c := make(chan int, 10)
+	sc.Stream().Where(func(d int) bool { return d%2 == 0 }).Listen(func(d int) {
+		c <- d
+	})
+	sc.Stream().ListenNonBlocking(func(d int) {
+		c <- -d
+	})
+	for i := 1; i <= 4; i++ {
+		sc.Add(i)
+	}
+
+	var even, nonBlocking []int
+	for i := 0; i < 6; i++ {
+		select {
+		case d := <-c:
+			if d < 0 {
+				nonBlocking = append(nonBlocking, d)
+			} else {
+				even = append(even, d)
+			}
+		case <-time.After(10 * time.Millisecond):
+			t.Fatal("no response")
+		}
+	}
+	if len(even) != 2 || even[0] != 2 || even[1] != 4 {
+		t.Error("got wrong data", even)
+	}
+	if len(nonBlocking) != 4 {
+		t.Error("got wrong data", nonBlocking)
+	}
+}
+
+func TestCompleteOnWithExecutor(t *testing.T) {
+	qe := &queueExecutor{}
+	c := NewCompleterWithExecutor[int](qe)
+	c.CompleteOn(func() (int, error) {
+		return 1, nil
+	})
+	if len(qe.fs) != 1 {
+		t.Fatal("function wasn't passed to the executor")
+	}
+	qe.run()
+	if !c.Completed() || c.Future().Result() != 1 {
+		t.Error("completer didn't complete")
+	}
+}
+
+func TestReactorWithExecutor(t *testing.T) {
+	wp := NewWorkerPool(2)
+	defer wp.Shutdown(nil)
+	r := NewReactorWithExecutor[int](wp)
+	defer r.Shutdown(nil)
+	c := make(chan int, 2)
+	r.React("test", func(d int) {
+		c <- d
+	})
+
+	r.Fire("test", 1)
+	r.FireIn("test", 2, 1*time.Millisecond)
+	for i := 1; i <= 2; i++ {
+		select {
+		case d := <-c:
+			if d != i {
+				t.Error("got wrong data", d)
+			}
+		case <-time.After(10 * time.Millisecond):
+			t.Fatal("no response")
+		}
+	}
+}
+
+func TestStreamControllerWithInlineExecutorReentrant(t *testing.T) {
+	sc := NewStreamControllerWithExecutor[int](InlineExecutor{})
+	c := make(chan int, 3)
+	sc.Stream().Listen(func(d int) {
+		c <- d
+		if d < 3 {
+			sc.Add(d + 1)
+		}
+	})
+
+	sc.Add(1)
+	for i := 1; i <= 3; i++ {
+		select {
+		case d := <-c:
+			if d != i {
+				t.Error("got wrong data", d)
+			}
+		case <-time.After(10 * time.Millisecond):
+			t.Fatal("no response")
+		}
+	}
+}
+
+func TestReactorWithInlineExecutorReentrantFire(t *testing.T) {
+	r := NewReactorWithExecutor[int](InlineExecutor{})
+	defer r.Shutdown(nil)
+	c := make(chan int, 2)
+	r.React("a", func(d int) {
+		c <- d
+		r.Fire("b", d+1)
+	})
+	r.React("b", func(d int) {
+		c <- d
+	})
+
+	done := make(chan struct{})
+	go func() {
+		r.Fire("a", 1)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(10 * time.Millisecond):
+		t.Fatal("fire deadlocked")
+	}
+	for i := 1; i <= 2; i++ {
+		select {
+		case d := <-c:
+			if d != i {
+				t.Error("got wrong data", d)
+			}
+		case <-time.After(10 * time.Millisecond):
+			t.Fatal("no response")
+		}
+	}
+}
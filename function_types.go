@@ -3,13 +3,20 @@ package eventual2go
 // A CompletionFunc is the argument for Completer.OnChange.
 type CompletionFunc[T any] func() (T, error)
 
-// A CompletionHandler gets invoked when a Future is completed. Returned value gets propagated when chaining futures.
+// A CompletionHandler gets invoked when a Future is completed.
 type CompletionHandler[T any] func(T)
 
-// An ErrorHandler gets invoked when a Future fails. Returned value and error are propagated when chaining futures, if
-// the error is nil, the chained future will be completed with the data, otherwise it fails.
+// An ErrorHandler gets invoked when a Future fails.
 type ErrorHandler func(error)
 
+// A FutureTransformer gets invoked when a Future is completed and returns a Future, which result gets propagated when
+// chaining futures with FlatMapFuture.
+type FutureTransformer[T, V any] func(T) *Future[V]
+
+// An ErrorRecoverer gets invoked when a Future fails. Returned value and error are propagated when chaining futures
+// with RecoverFuture, if the error is nil, the chained future will be completed with the data, otherwise it fails.
+type ErrorRecoverer[T any] func(error) (T, error)
+
 // A Subscriber gets invoked whenever data is added to the consumed stream.
 type Subscriber[T any] func(T)
 
@@ -26,3 +33,12 @@ type TransformerConditional[T, V any] func(T) (V, bool)
 // A Filter gets invoked when data is added to the consumed stream. The data is added to filtered stream conditionally,
 // depending the Filter got registered with Where or WhereNot.
 type Filter[T any] func(T) bool
+
+// An Accumulator gets invoked when data is added to the consumed stream and returns the new accumulated value.
+type Accumulator[T, A any] func(A, T) A
+
+// A Comparator gets invoked to check two elements for equality.
+type Comparator[T any] func(T, T) bool
+
+// A PanicHandler gets invoked with the recovered value, when a handler function panics.
+type PanicHandler func(*PanicError)
@@ -1,6 +1,7 @@
 package eventual2go
 
 import (
+	"context"
 	"fmt"
 	"sync"
 	"time"
@@ -15,32 +16,77 @@ type Future[T any] struct {
 	completed bool
 	result    T
 	err       error
+	mode      DispatchMode
+	executor  Executor
+	queue     []func() // pending dispatches, see dispatchInOrder
+	running   bool     // whether a go-routine is dispatching the queue
 }
 
 // Creates a new future.
 func newFuture[T any]() (F *Future[T]) {
+	return newFutureWithDispatch[T](DispatchDefault, nil)
+}
+
+// Creates a new future with the given DispatchMode. A DispatchExecutor without Executor falls back to DispatchAsync.
+func newFutureWithDispatch[T any](mode DispatchMode, e Executor) (F *Future[T]) {
+	if mode == DispatchExecutor && e == nil {
+		mode = DispatchAsync
+	}
 	F = &Future[T]{
 		m:         new(sync.RWMutex),
 		fcs:       []CompletionHandler[T]{},
 		fces:      []ErrorHandler{},
 		completed: false,
+		mode:      mode,
+		executor:  e,
 	}
 	return
 }
 
+// Dispatch returns the DispatchMode of the future.
+func (f *Future[T]) Dispatch() DispatchMode {
+	return f.mode
+}
+
 // Completes the future with the given data and triggers al registered completion handlers. Panics if the future is already
 // complete.
 func (f *Future[T]) complete(d T) {
+	if !f.tryComplete(d) {
+		panic(fmt.Sprint("Completed complete future with", d))
+	}
+}
+
+// Completes the future with the given data and triggers al registered completion handlers. Returns false if the future
+// is already complete.
+func (f *Future[T]) tryComplete(d T) (ok bool) {
 	f.m.Lock()
-	defer f.m.Unlock()
 	if f.completed {
-		panic(fmt.Sprint("Completed complete future with", d))
+		f.m.Unlock()
+		return
 	}
 	f.result = d
+	f.completed = true
+	ok = true
+	if f.mode == DispatchDefault {
+		fcs := f.fcs
+		f.m.Unlock()
+		for _, fc := range fcs {
+			f.dispatchCompletion(fc, d)
+		}
+		return
+	}
 	for _, fc := range f.fcs {
-		go fc(d)
+		f.enqueueCompletion(fc, d)
 	}
-	f.completed = true
+	f.dispatchInOrder()
+	return
+}
+
+// Returns the number of registered completion handlers.
+func (f *Future[T]) handlerCount() int {
+	f.m.RLock()
+	defer f.m.RUnlock()
+	return len(f.fcs)
 }
 
 // Completed returns the completion state.
@@ -53,27 +99,53 @@ func (f *Future[T]) Completed() bool {
 // Completes the future with the given error and triggers al registered error handlers. Panics if the future is already
 // complete.
 func (f *Future[T]) completeError(err error) {
+	if !f.tryCompleteError(err) {
+		panic(fmt.Sprint("Errorcompleted complete future with", err))
+	}
+}
+
+// Completes the future with the given error and triggers al registered error handlers. Returns false if the future is
+// already complete.
+func (f *Future[T]) tryCompleteError(err error) (ok bool) {
 	f.m.Lock()
-	defer f.m.Unlock()
 	if f.completed {
-		panic(fmt.Sprint("Errorcompleted complete future with", err))
+		f.m.Unlock()
+		return
 	}
 	f.err = err
-	for _, fce := range f.fces {
-		fce(f.err)
+	if f.mode == DispatchDefault {
+		// error handlers are invoked with the lock held, so they finish before the future reports completion
+		defer f.m.Unlock()
+		for _, fce := range f.fces {
+			runErrorHandler(fce, err)
+		}
+		f.completed = true
+		ok = true
+		return
 	}
 	f.completed = true
+	for _, fce := range f.fces {
+		f.enqueueError(fce, err)
+	}
+	f.dispatchInOrder()
+	ok = true
+	return
 }
 
 
 // Then registers a completion handler. If the future is already complete, the handler gets executed immediately.
-// Returns a future that gets completed with result of the handler.
+// Use MapFuture or FlatMapFuture to chain futures.
 func (f *Future[T]) Then(ch CompletionHandler[T]) {
 
 	f.m.Lock()
 	if f.completed && f.err == nil {
-		f.m.Unlock()
-		ch(f.result)
+		if f.mode == DispatchDefault {
+			f.m.Unlock()
+			runCompletionHandler(ch, f.result)
+		} else {
+			f.enqueueCompletion(ch, f.result)
+			f.dispatchInOrder()
+		}
 		return
 	} else if !f.completed {
 		f.fcs = append(f.fcs, ch)
@@ -89,16 +161,63 @@ func (f *Future[T]) WaitUntilComplete() {
 
 // WaitUntilTimeout blocks until the future is complete or the timeout is reached.
 func (f *Future [T]) WaitUntilTimeout(timeout time.Duration) (complete bool) {
+	return f.WaitUntilTimeoutWithClock(timeout, RealClock{})
+}
+
+// WaitUntilTimeoutWithClock is the same as WaitUntilTimeout, but measures the timeout with the given Clock.
+func (f *Future[T]) WaitUntilTimeoutWithClock(timeout time.Duration, clock Clock) (complete bool) {
 	if !f.Completed() {
+		t := clock.NewTimer(timeout)
+		defer t.Stop()
 		select {
-		case <-f.AsChan():
-		case <-time.After(timeout):
+		case <-f.AsErrChan():
+		case <-t.C():
 		}
 	}
 	complete = f.Completed()
 	return
 }
 
+// Await blocks until the future is complete or the context is done. Returns the result and the error of the future or
+// the error of the context, if it is done first.
+func (f *Future[T]) Await(ctx context.Context) (res T, err error) {
+	if !f.Completed() {
+		select {
+		case <-f.AsErrChan():
+		case <-ctx.Done():
+			err = ctx.Err()
+			return
+		}
+	}
+	f.m.RLock()
+	defer f.m.RUnlock()
+	res, err = f.result, f.err
+	return
+}
+
+// Get blocks until the future is complete and returns its result and error.
+func (f *Future[T]) Get() (res T, err error) {
+	return f.Await(context.Background())
+}
+
+// GetTimeout blocks until the future is complete or the timeout is reached. Returns the result and error of the future or
+// ErrTimeout, if the timeout is reached first.
+func (f *Future[T]) GetTimeout(timeout time.Duration) (res T, err error) {
+	return f.GetTimeoutWithClock(timeout, RealClock{})
+}
+
+// GetTimeoutWithClock is the same as GetTimeout, but measures the timeout with the given Clock.
+func (f *Future[T]) GetTimeoutWithClock(timeout time.Duration, clock Clock) (res T, err error) {
+	if !f.WaitUntilTimeoutWithClock(timeout, clock) {
+		err = ErrTimeout
+		return
+	}
+	f.m.RLock()
+	defer f.m.RUnlock()
+	res, err = f.result, f.err
+	return
+}
+
 // Result returns the result of the future, nil if called before completion or after error completion.
 func (f *Future[T]) Result() T {
 	return f.result
@@ -111,14 +230,18 @@ func (f *Future[T]) ErrResult() error {
 
 // Err registers an error handler. If the future is already completed with an error, the handler gets executed
 // immediately.
-// Returns a future that either gets completed with result of the handler or error completed with the error from handler,
-// if not nil.
+// Use RecoverFuture to chain futures.
 func (f *Future[T]) Err(eh ErrorHandler)  {
 	f.m.Lock()
 
 	if f.err != nil {
-		f.m.Unlock()
-		eh(f.err)
+		if f.mode == DispatchDefault {
+			f.m.Unlock()
+			runErrorHandler(eh, f.err)
+		} else {
+			f.enqueueError(eh, f.err)
+			f.dispatchInOrder()
+		}
 		return
 	} else if !f.completed {
 		f.fces = append(f.fces, eh)
@@ -164,3 +287,68 @@ func (f *Future[T]) AsErrChan() chan error {
 	f.Err(ecmpl(c))
 	return c
 }
+
+// deriveCompleter creates a new Completer, which Future has the same DispatchMode as the given one.
+func deriveCompleter[T, V any](f *Future[T]) *Completer[V] {
+	return &Completer[V]{newFutureWithDispatch[V](f.mode, f.executor)}
+}
+
+// MapFuture returns a Future which gets completed with the result of the Transformer applied to the result of the
+// source Future. If the source Future fails, the returned Future fails with the same error. The returned Future has the
+// same DispatchMode as the source Future.
+func MapFuture[T, V any](f *Future[T], t Transformer[T, V]) (mf *Future[V]) {
+	c := deriveCompleter[T, V](f)
+	mf = c.Future()
+	f.Then(mapFuture(c, t))
+	f.Err(completeFutureError(c))
+	return
+}
+
+func mapFuture[T, V any](c *Completer[V], t Transformer[T, V]) CompletionHandler[T] {
+	return func(d T) {
+		defer failOnPanic(c)
+		c.Complete(t(d))
+	}
+}
+
+// FlatMapFuture returns a Future which gets completed with the result of the Future returned by the FutureTransformer.
+// If either the source Future or the returned Future fail, the returned Future fails with the same error. The returned
+// Future has the same DispatchMode as the source Future.
+func FlatMapFuture[T, V any](f *Future[T], t FutureTransformer[T, V]) (mf *Future[V]) {
+	c := deriveCompleter[T, V](f)
+	mf = c.Future()
+	f.Then(flatMapFuture(c, t))
+	f.Err(completeFutureError(c))
+	return
+}
+
+func flatMapFuture[T, V any](c *Completer[V], t FutureTransformer[T, V]) CompletionHandler[T] {
+	return func(d T) {
+		defer failOnPanic(c)
+		c.CompleteOnFuture(t(d))
+	}
+}
+
+// RecoverFuture returns a Future which gets completed with the result of the source Future. If the source Future
+// fails, the ErrorRecoverer is invoked and the returned Future either gets completed with the recovered data or fails
+// with the error returned by the ErrorRecoverer, if not nil. The returned Future has the same DispatchMode as the source
+// Future.
+func RecoverFuture[T any](f *Future[T], r ErrorRecoverer[T]) (rf *Future[T]) {
+	c := deriveCompleter[T, T](f)
+	rf = c.Future()
+	f.Then(completeFuture(c))
+	f.Err(recoverFuture(c, r))
+	return
+}
+
+func recoverFuture[T any](c *Completer[T], r ErrorRecoverer[T]) ErrorHandler {
+	return func(err error) {
+		defer failOnPanic(c)
+		d, err := r(err)
+		if err == nil {
+			c.Complete(d)
+		} else {
+			c.CompleteError(err)
+		}
+	}
+}
@@ -0,0 +1,160 @@
+package eventual2go
+
+import (
+	"errors"
+	"sync"
+)
+
+// ErrNoFutures represents the error of aggregating an empty list of futures, where at least one is required.
+var ErrNoFutures = errors.New("No futures")
+
+// FutureResult represents the outcome of a completed Future, which is either a result or an error.
+type FutureResult[T any] struct {
+	Result T
+	Err    error
+}
+
+// AllFutures returns a Future which gets completed with the results of all given futures in the order of the given
+// list. The returned Future fails with the first error of any of the given futures.
+func AllFutures[T any](fs []*Future[T]) (f *Future[[]T]) {
+	c := NewCompleter[[]T]()
+	f = c.Future()
+	if len(fs) == 0 {
+		c.Complete([]T{})
+		return
+	}
+	a := &allFutures[T]{
+		c:       c,
+		results: make([]T, len(fs)),
+		pending: len(fs),
+	}
+	for i, ff := range fs {
+		ff.Then(a.onComplete(i))
+		ff.Err(tryCompleteFutureError(c))
+	}
+	return
+}
+
+type allFutures[T any] struct {
+	m       sync.Mutex
+	c       *Completer[[]T]
+	results []T
+	pending int
+}
+
+func (a *allFutures[T]) onComplete(i int) CompletionHandler[T] {
+	return func(d T) {
+		a.m.Lock()
+		a.results[i] = d
+		a.pending--
+		done := a.pending == 0
+		a.m.Unlock()
+		if done {
+			a.c.TryComplete(a.results)
+		}
+	}
+}
+
+// AnyFuture returns a Future which gets completed with the result of the first of the given futures which completes
+// successfully. If all given futures fail, the returned Future fails with the error of the future which failed last.
+// An empty list fails with ErrNoFutures.
+func AnyFuture[T any](fs []*Future[T]) (f *Future[T]) {
+	c := NewCompleter[T]()
+	f = c.Future()
+	if len(fs) == 0 {
+		c.CompleteError(ErrNoFutures)
+		return
+	}
+	a := &anyFuture[T]{
+		c:       c,
+		pending: len(fs),
+	}
+	for _, ff := range fs {
+		ff.Then(tryCompleteFuture(c))
+		ff.Err(a.onError)
+	}
+	return
+}
+
+type anyFuture[T any] struct {
+	m       sync.Mutex
+	c       *Completer[T]
+	pending int
+}
+
+func (a *anyFuture[T]) onError(err error) {
+	a.m.Lock()
+	a.pending--
+	done := a.pending == 0
+	a.m.Unlock()
+	if done {
+		a.c.TryCompleteError(err)
+	}
+}
+
+// RaceFutures returns a Future which gets completed with the result or the error of the first of the given futures
+// which completes. An empty list fails with ErrNoFutures.
+func RaceFutures[T any](fs []*Future[T]) (f *Future[T]) {
+	c := NewCompleter[T]()
+	f = c.Future()
+	if len(fs) == 0 {
+		c.CompleteError(ErrNoFutures)
+		return
+	}
+	for _, ff := range fs {
+		ff.Then(tryCompleteFuture(c))
+		ff.Err(tryCompleteFutureError(c))
+	}
+	return
+}
+
+// AllSettled returns a Future which gets completed after all given futures have completed, either successfully or with
+// an error. The outcomes are in the order of the given list. The returned Future never fails.
+func AllSettled[T any](fs []*Future[T]) (f *Future[[]FutureResult[T]]) {
+	c := NewCompleter[[]FutureResult[T]]()
+	f = c.Future()
+	if len(fs) == 0 {
+		c.Complete([]FutureResult[T]{})
+		return
+	}
+	a := &allSettled[T]{
+		c:       c,
+		results: make([]FutureResult[T], len(fs)),
+		pending: len(fs),
+	}
+	for i, ff := range fs {
+		ff.Then(a.onComplete(i))
+		ff.Err(a.onError(i))
+	}
+	return
+}
+
+type allSettled[T any] struct {
+	m       sync.Mutex
+	c       *Completer[[]FutureResult[T]]
+	results []FutureResult[T]
+	pending int
+}
+
+func (a *allSettled[T]) onComplete(i int) CompletionHandler[T] {
+	return func(d T) {
+		a.settle(i, FutureResult[T]{Result: d})
+	}
+}
+
+func (a *allSettled[T]) onError(i int) ErrorHandler {
+	return func(err error) {
+		a.settle(i, FutureResult[T]{Err: err})
+	}
+}
+
+func (a *allSettled[T]) settle(i int, r FutureResult[T]) {
+	a.m.Lock()
+	a.results[i] = r
+	a.pending--
+	done := a.pending == 0
+	a.m.Unlock()
+	if done {
+		a.c.TryComplete(a.results)
+	}
+}
@@ -0,0 +1,139 @@
+package eventual2go
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func testFutures(n int) (cs []*Completer[int], fs []*Future[int]) {
+	for i := 0; i < n; i++ {
+		c := NewCompleter[int]()
+		cs = append(cs, c)
+		fs = append(fs, c.Future())
+	}
+	return
+}
+
+func TestAllFutures(t *testing.T) {
+	cs, fs := testFutures(3)
+	f := AllFutures(fs)
+
+	cs[2].Complete(2)
+	cs[0].Complete(0)
+	time.Sleep(1 * time.Millisecond)
+	if f.Completed() {
+		t.Error("Complete to early")
+	}
+	cs[1].Complete(1)
+
+	res, err := f.GetTimeout(10 * time.Millisecond)
+	if err != nil {
+		t.Fatal("Got error", err)
+	}
+	for i, r := range res {
+		if r != i {
+			t.Error("Completed with wrong args", res)
+		}
+	}
+}
+
+func TestAllFuturesErr(t *testing.T) {
+	cs, fs := testFutures(2)
+	f := AllFutures(fs)
+
+	cs[1].CompleteError(errors.New("testerror"))
+
+	if _, err := f.GetTimeout(10 * time.Millisecond); err == nil || err.Error() != "testerror" {
+		t.Error("Completed with wrong err", err)
+	}
+	cs[0].Complete(0)
+}
+
+func TestAllFuturesEmpty(t *testing.T) {
+	res, err := AllFutures([]*Future[int]{}).GetTimeout(10 * time.Millisecond)
+	if err != nil || len(res) != 0 {
+		t.Error("Completed with wrong args", res, err)
+	}
+}
+
+func TestAnyFuture(t *testing.T) {
+	cs, fs := testFutures(3)
+	f := AnyFuture(fs)
+
+	cs[0].CompleteError(errors.New("testerror"))
+	cs[2].Complete(2)
+	time.Sleep(1 * time.Millisecond)
+	cs[1].Complete(1)
+
+	if res, err := f.GetTimeout(10 * time.Millisecond); res != 2 || err != nil {
+		t.Error("Completed with wrong args", res, err)
+	}
+}
+
+func TestAnyFutureErr(t *testing.T) {
+	cs, fs := testFutures(2)
+	f := AnyFuture(fs)
+
+	cs[0].CompleteError(errors.New("testerror1"))
+	time.Sleep(1 * time.Millisecond)
+	if f.Completed() {
+		t.Error("Complete to early")
+	}
+	cs[1].CompleteError(errors.New("testerror2"))
+
+	if _, err := f.GetTimeout(10 * time.Millisecond); err == nil || err.Error() != "testerror2" {
+		t.Error("Completed with wrong err", err)
+	}
+
+	if _, err := AnyFuture([]*Future[int]{}).GetTimeout(10 * time.Millisecond); err != ErrNoFutures {
+		t.Error("Completed with wrong err", err)
+	}
+}
+
+func TestRaceFutures(t *testing.T) {
+	cs, fs := testFutures(2)
+	f := RaceFutures(fs)
+
+	cs[1].CompleteError(errors.New("testerror"))
+	time.Sleep(1 * time.Millisecond)
+	cs[0].Complete(0)
+
+	if _, err := f.GetTimeout(10 * time.Millisecond); err == nil || err.Error() != "testerror" {
+		t.Error("Completed with wrong err", err)
+	}
+
+	cs, fs = testFutures(2)
+	f = RaceFutures(fs)
+
+	cs[1].Complete(1)
+	time.Sleep(1 * time.Millisecond)
+	cs[0].CompleteError(errors.New("testerror"))
+
+	if res, err := f.GetTimeout(10 * time.Millisecond); res != 1 || err != nil {
+		t.Error("Completed with wrong args", res, err)
+	}
+}
+
+func TestAllSettled(t *testing.T) {
+	cs, fs := testFutures(2)
+	f := AllSettled(fs)
+
+	cs[1].CompleteError(errors.New("testerror"))
+	time.Sleep(1 * time.Millisecond)
+	if f.Completed() {
+		t.Error("Complete to early")
+	}
+	cs[0].Complete(42)
+
+	res, err := f.GetTimeout(10 * time.Millisecond)
+	if err != nil {
+		t.Fatal("Got error", err)
+	}
+	if res[0].Result != 42 || res[0].Err != nil {
+		t.Error("Completed with wrong args", res[0])
+	}
+	if res[1].Err == nil || res[1].Err.Error() != "testerror" {
+		t.Error("Completed with wrong err", res[1])
+	}
+}
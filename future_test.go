@@ -1,7 +1,9 @@
 package eventual2go
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"testing"
 	"time"
 )
@@ -118,6 +120,296 @@ func TestFutureMultiCompletion(t *testing.T) {
 	}
 }
 
+func TestFutureMap(t *testing.T) {
+	cp := NewCompleter[int]()
+	f := MapFuture(cp.Future(), func(d int) string { return fmt.Sprint(d * 2) })
+
+	c := f.AsChan()
+
+	cp.Complete(21)
+
+	if (<-c) != "42" {
+		t.Error("Completed with wrong args")
+	}
+}
+
+func TestFutureMapErr(t *testing.T) {
+	cp := NewCompleter[int]()
+	f := MapFuture(cp.Future(), func(d int) string { return fmt.Sprint(d) })
+
+	c := f.AsErrChan()
+
+	cp.CompleteError(errors.New("testerror"))
+
+	if (<-c).Error() != "testerror" {
+		t.Error("Completed with wrong err")
+	}
+}
+
+func TestFutureFlatMap(t *testing.T) {
+	cp1 := NewCompleter[int]()
+	cp2 := NewCompleter[string]()
+	f := FlatMapFuture(cp1.Future(), func(d int) *Future[string] {
+		if d != 1 {
+			t.Error("Completed with wrong args")
+		}
+		return cp2.Future()
+	})
+
+	c := f.AsChan()
+
+	cp1.Complete(1)
+	time.Sleep(1 * time.Millisecond)
+	if f.Completed() {
+		t.Error("Complete to early")
+	}
+	cp2.Complete("test")
+
+	if (<-c) != "test" {
+		t.Error("Completed with wrong args")
+	}
+}
+
+func TestFutureFlatMapErr(t *testing.T) {
+	cp1 := NewCompleter[int]()
+	cp2 := NewCompleter[string]()
+	f := FlatMapFuture(cp1.Future(), func(int) *Future[string] { return cp2.Future() })
+
+	c := f.AsErrChan()
+
+	cp1.Complete(1)
+	cp2.CompleteError(errors.New("testerror"))
+
+	if (<-c).Error() != "testerror" {
+		t.Error("Completed with wrong err")
+	}
+}
+
+func TestFutureRecover(t *testing.T) {
+	cp := NewCompleter[int]()
+	f := RecoverFuture(cp.Future(), func(err error) (int, error) {
+		if err.Error() != "testerror" {
+			t.Error("Recovered wrong err")
+		}
+		return 42, nil
+	})
+
+	c := f.AsChan()
+
+	cp.CompleteError(errors.New("testerror"))
+
+	if (<-c) != 42 {
+		t.Error("Completed with wrong args")
+	}
+}
+
+func TestFutureRecoverErr(t *testing.T) {
+	cp := NewCompleter[int]()
+	f := RecoverFuture(cp.Future(), func(err error) (int, error) {
+		return 0, errors.New("othererror")
+	})
+
+	c := f.AsErrChan()
+
+	cp.CompleteError(errors.New("testerror"))
+
+	if (<-c).Error() != "othererror" {
+		t.Error("Completed with wrong err")
+	}
+}
+
+func TestFutureRecoverPassThrough(t *testing.T) {
+	cp := NewCompleter[int]()
+	f := RecoverFuture(cp.Future(), func(err error) (int, error) {
+		t.Error("Recovered without error")
+		return 0, nil
+	})
+
+	c := f.AsChan()
+
+	cp.Complete(42)
+
+	if (<-c) != 42 {
+		t.Error("Completed with wrong args")
+	}
+}
+
+func TestCompleterWithContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cp := NewCompleterWithContext[bool](ctx)
+	f := cp.Future()
+
+	c := f.AsErrChan()
+
+	cancel()
+
+	if <-c != context.Canceled {
+		t.Error("Completed with wrong err")
+	}
+}
+
+func TestCompleterWithContextCompletion(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cp := NewCompleterWithContext[bool](ctx)
+	f := cp.Future()
+
+	cp.Complete(true)
+	cancel()
+	time.Sleep(1 * time.Millisecond)
+
+	if f.ErrResult() != nil {
+		t.Error("Error completed after completion")
+	}
+	if !f.Result() {
+		t.Error("Completed with wrong args")
+	}
+}
+
+func TestFutureAwait(t *testing.T) {
+	cp := NewCompleter[int]()
+	f := cp.Future()
+
+	go cp.Complete(42)
+
+	res, err := f.Await(context.Background())
+	if err != nil {
+		t.Fatal("Got error", err)
+	}
+	if res != 42 {
+		t.Error("Completed with wrong args")
+	}
+
+	cp = NewCompleter[int]()
+	f = cp.Future()
+
+	go cp.CompleteError(errors.New("testerror"))
+
+	if _, err = f.Await(context.Background()); err == nil || err.Error() != "testerror" {
+		t.Error("Completed with wrong err", err)
+	}
+}
+
+func TestFutureAwaitContextDone(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 1*time.Millisecond)
+	defer cancel()
+	f := NewCompleter[int]().Future()
+
+	if _, err := f.Await(ctx); err != context.DeadlineExceeded {
+		t.Error("Completed with wrong err", err)
+	}
+}
+
+func TestFutureGet(t *testing.T) {
+	cp := NewCompleter[int]()
+	f := cp.Future()
+
+	go cp.Complete(42)
+
+	res, err := f.Get()
+	if err != nil {
+		t.Fatal("Got error", err)
+	}
+	if res != 42 {
+		t.Error("Completed with wrong args")
+	}
+}
+
+func TestFutureGetTimeout(t *testing.T) {
+	cp := NewCompleter[int]()
+	f := cp.Future()
+
+	if _, err := f.GetTimeout(1 * time.Millisecond); err != ErrTimeout {
+		t.Error("Completed with wrong err", err)
+	}
+
+	cp.CompleteError(errors.New("testerror"))
+
+	if _, err := f.GetTimeout(1 * time.Millisecond); err == nil || err.Error() != "testerror" {
+		t.Error("Completed with wrong err", err)
+	}
+}
+
+func TestCompleterTryComplete(t *testing.T) {
+	cp := NewCompleter[int]()
+
+	if !cp.TryComplete(1) {
+		t.Error("First completion failed")
+	}
+	if cp.TryComplete(2) {
+		t.Error("Second completion succeeded")
+	}
+	if cp.TryCompleteError(errors.New("testerror")) {
+		t.Error("Error completion succeeded")
+	}
+	if res, err := cp.Future().Get(); res != 1 || err != nil {
+		t.Error("Completed with wrong args", res, err)
+	}
+}
+
+func TestTimeoutCompleterRace(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		cp := NewTimeoutCompleter[int](time.Duration(i) * time.Microsecond)
+		time.Sleep(time.Duration(100-i) * time.Microsecond)
+		ok := cp.TryComplete(i)
+		res, err := cp.Future().GetTimeout(10 * time.Millisecond)
+		if ok && (res != i || err != nil) {
+			t.Error("TryComplete succeeded, but future completed with", res, err)
+		}
+		if !ok && err != ErrTimeout {
+			t.Error("TryComplete failed, but future completed with", res, err)
+		}
+	}
+}
+
+func TestTimeoutCompleterCompleteOnRace(t *testing.T) {
+	for i := 0; i < 100; i++ {
+		cp := NewTimeoutCompleter[int](time.Duration(i) * time.Microsecond)
+		i := i
+		cp.CompleteOn(func() (int, error) {
+			time.Sleep(time.Duration(100-i) * time.Microsecond)
+			return i, nil
+		})
+		if res, err := cp.Future().GetTimeout(10 * time.Millisecond); err != ErrTimeout && res != i {
+			t.Error("completed with wrong result", res, err)
+		}
+	}
+	time.Sleep(1 * time.Millisecond)
+}
+
+func TestTimeoutCompletionWithClock(t *testing.T) {
+	mc := NewManualClock(time.Unix(0, 0))
+	cp := NewTimeoutCompleterWithClock[bool](1*time.Second, mc)
+	f := cp.Future()
+
+	mc.BlockUntil(1)
+	mc.Advance(999 * time.Millisecond)
+	if f.Completed() {
+		t.Error("Timeout completed to early")
+	}
+	mc.Advance(1 * time.Millisecond)
+
+	if err := <-f.AsErrChan(); err != ErrTimeout {
+		t.Error("Completed with wrong error")
+	}
+}
+
+func TestFutureWaitUntilTimeoutWithClock(t *testing.T) {
+	mc := NewManualClock(time.Unix(0, 0))
+	f := NewCompleter[bool]().Future()
+
+	done := make(chan bool)
+	go func() {
+		done <- f.WaitUntilTimeoutWithClock(1*time.Second, mc)
+	}()
+
+	mc.BlockUntil(1)
+	mc.Advance(1 * time.Second)
+
+	if <-done {
+		t.Error("Future reported complete")
+	}
+}
+
 func testcompleter(c chan interface{}) CompletionHandler[Data] {
 	return func(d Data) {
 		c <- d
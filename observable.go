@@ -36,7 +36,7 @@ func (o *Observable[T]) Change(value T) {
 }
 
 // OnChange registers a subscriber for change events.
-func (o *Observable[T]) OnChange(subscriber Subscriber[T]) (cancel *Completer[Data]) {
+func (o *Observable[T]) OnChange(subscriber Subscriber[T]) (sub *Subscription) {
 	return o.change.Stream().Listen(subscriber)
 }
 
@@ -52,7 +52,7 @@ func (o *Observable[T]) Stream() (stream *Stream[T]) {
 }
 
 // AsChan returns a channel on which changes get send.
-func (o *Observable[T]) AsChan() (c chan T, cancel *Completer[Data]) {
+func (o *Observable[T]) AsChan() (c chan T, sub *Subscription) {
 	return o.change.Stream().AsChan()
 }
 
@@ -62,9 +62,9 @@ func (o *Observable[T]) NextChange() (f *Future[T]) {
 }
 
 // Derive returns a new Observable which value will be set by transform function everytime the source gets updated.
-func DeriveObservable[T, V any](o *Observable[T], t Transformer[T, V]) (do *Observable[V], cancel *Completer[Data]) {
+func DeriveObservable[T, V any](o *Observable[T], t Transformer[T, V]) (do *Observable[V], sub *Subscription) {
 	do = NewObservable(t(o.Value()))
-	cancel = o.OnChange(func(d T) {
+	sub = o.OnChange(func(d T) {
 		do.Change(t(d))
 	})
 	return
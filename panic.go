@@ -0,0 +1,94 @@
+package eventual2go
+
+import (
+	"fmt"
+	"runtime/debug"
+	"sync"
+)
+
+// PanicError wraps a value recovered from a panicking handler function.
+type PanicError struct {
+	Value interface{} // the value passed to panic
+	Stack []byte      // the stack trace of the panicking go-routine
+}
+
+func newPanicError(v interface{}) *PanicError {
+	return &PanicError{
+		Value: v,
+		Stack: debug.Stack(),
+	}
+}
+
+func (e *PanicError) Error() string {
+	return fmt.Sprint("Handler panicked: ", e.Value)
+}
+
+var panics = struct {
+	m        sync.RWMutex
+	handler  PanicHandler
+	asErrors bool
+}{}
+
+// SetPanicHandler sets the PanicHandler, which gets invoked for panics in subscribers and completion or error handlers,
+// unless a more specific handler is set, e.g. with Stream.SetPanicHandler. If no PanicHandler is set, which is the
+// default, panics are not recovered. Setting nil disables the recovery.
+func SetPanicHandler(h PanicHandler) {
+	panics.m.Lock()
+	defer panics.m.Unlock()
+	panics.handler = h
+}
+
+// SetPanicsAsErrors enables or disables the conversion of panics in functions deriving a Future, like the Transformer
+// of MapFuture or the CompletionFunc of Completer.CompleteOn. If enabled, the derived Future fails with a PanicError
+// instead of the panic being passed to the PanicHandler.
+func SetPanicsAsErrors(enabled bool) {
+	panics.m.Lock()
+	defer panics.m.Unlock()
+	panics.asErrors = enabled
+}
+
+func panicHandler() PanicHandler {
+	panics.m.RLock()
+	defer panics.m.RUnlock()
+	return panics.handler
+}
+
+func panicsAsErrors() bool {
+	panics.m.RLock()
+	defer panics.m.RUnlock()
+	return panics.asErrors
+}
+
+// recoverPanic passes a panic to the given PanicHandler or, if nil, to the package-level one. If no PanicHandler is set
+// the panic is not recovered. Must be deferred directly.
+func recoverPanic(h PanicHandler) {
+	if h == nil {
+		h = panicHandler()
+	}
+	if h == nil {
+		return
+	}
+	if v := recover(); v != nil {
+		h(newPanicError(v))
+	}
+}
+
+// failOnPanic fails the Completer with a PanicError, if panics are converted to errors. Must be deferred directly.
+func failOnPanic[T any](c *Completer[T]) {
+	if !panicsAsErrors() {
+		return
+	}
+	if v := recover(); v != nil {
+		c.TryCompleteError(newPanicError(v))
+	}
+}
+
+func runCompletionHandler[T any](ch CompletionHandler[T], d T) {
+	defer recoverPanic(nil)
+	ch(d)
+}
+
+func runErrorHandler(eh ErrorHandler, err error) {
+	defer recoverPanic(nil)
+	eh(err)
+}
@@ -0,0 +1,156 @@
+package eventual2go
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func panicsTo(c chan *PanicError) PanicHandler {
+	return func(err *PanicError) {
+		c <- err
+	}
+}
+
+func checkPanic(t *testing.T, c chan *PanicError) {
+	t.Helper()
+	select {
+	case err := <-c:
+		if err.Value != "testpanic" || len(err.Stack) == 0 {
+			t.Error("got wrong panic", err)
+		}
+	case <-time.After(10 * time.Millisecond):
+		t.Fatal("panic wasn't handled")
+	}
+}
+
+func TestFutureCompletionHandlerPanic(t *testing.T) {
+	c := make(chan *PanicError, 1)
+	SetPanicHandler(panicsTo(c))
+	defer SetPanicHandler(nil)
+
+	cmp := NewCompleter[int]()
+	cmp.Future().Then(func(int) {
+		panic("testpanic")
+	})
+	cmp.Complete(1)
+	checkPanic(t, c)
+}
+
+func TestFutureErrorHandlerPanic(t *testing.T) {
+	c := make(chan *PanicError, 1)
+	SetPanicHandler(panicsTo(c))
+	defer SetPanicHandler(nil)
+
+	cmp := NewCompleter[int]()
+	cmp.Future().Err(func(error) {
+		panic("testpanic")
+	})
+	cmp.CompleteError(ErrTimeout)
+	checkPanic(t, c)
+	if !cmp.Completed() {
+		t.Error("future didn't complete")
+	}
+}
+
+func TestFutureCompletedHandlerPanic(t *testing.T) {
+	c := make(chan *PanicError, 1)
+	SetPanicHandler(panicsTo(c))
+	defer SetPanicHandler(nil)
+
+	cmp := NewCompleter[int]()
+	cmp.Complete(1)
+	cmp.Future().Then(func(int) {
+		panic("testpanic")
+	})
+	checkPanic(t, c)
+
+	cmp = NewCompleter[int]()
+	cmp.CompleteError(ErrTimeout)
+	cmp.Future().Err(func(error) {
+		panic("testpanic")
+	})
+	checkPanic(t, c)
+}
+
+func TestStreamPanicHandler(t *testing.T) {
+	c := make(chan *PanicError, 1)
+	sc := NewStreamController[int]()
+	sc.Stream().SetPanicHandler(panicsTo(c))
+	received := make(chan int, 1)
+	sc.Stream().Listen(func(d int) {
+		if d == 1 {
+			panic("testpanic")
+		}
+		received <- d
+	})
+
+	sc.Add(1)
+	checkPanic(t, c)
+	sc.Add(2)
+	select {
+	case d := <-received:
+		if d != 2 {
+			t.Error("got wrong data", d)
+		}
+	case <-time.After(10 * time.Millisecond):
+		t.Fatal("subscription terminated after panic")
+	}
+}
+
+func TestMapFuturePanicAsError(t *testing.T) {
+	SetPanicsAsErrors(true)
+	defer SetPanicsAsErrors(false)
+
+	cmp := NewCompleter[int]()
+	mf := MapFuture(cmp.Future(), func(int) string {
+		panic("testpanic")
+	})
+	cmp.Complete(1)
+
+	var perr *PanicError
+	if _, err := mf.GetTimeout(10 * time.Millisecond); !errors.As(err, &perr) || perr.Value != "testpanic" {
+		t.Error("got wrong error", err)
+	}
+}
+
+func TestCompleteOnPanicAsError(t *testing.T) {
+	SetPanicsAsErrors(true)
+	defer SetPanicsAsErrors(false)
+
+	cmp := NewCompleter[int]()
+	cmp.CompleteOn(func() (int, error) {
+		panic("testpanic")
+	})
+
+	var perr *PanicError
+	if _, err := cmp.Future().GetTimeout(10 * time.Millisecond); !errors.As(err, &perr) {
+		t.Error("got wrong error", err)
+	}
+}
+
+func TestReactorOnPanic(t *testing.T) {
+	c := make(chan *PanicError, 1)
+	r := NewReactor[int]()
+	defer r.Shutdown(nil)
+	r.OnPanic(panicsTo(c))
+	received := make(chan int, 1)
+	r.React("panic", func(int) {
+		panic("testpanic")
+	})
+	r.React("test", func(d int) {
+		received <- d
+	})
+
+	r.Fire("panic", 0)
+	checkPanic(t, c)
+	r.Fire("test", 1)
+	select {
+	case d := <-received:
+		if d != 1 {
+			t.Error("got wrong data", d)
+		}
+	case <-time.After(10 * time.Millisecond):
+		t.Fatal("reactor stopped after panic")
+	}
+}
@@ -13,21 +13,44 @@ type ShutdownEvent struct{}
 // Reactor is thread-safe event handler.
 type Reactor[T any] struct {
 	*sync.Mutex
-	evtIn             *StreamController[Event[T]]
-	shutdownCompleter *Completer[Data]
-	shutdownReason    Data
-	eventRegister     map[interface{}]Subscriber[T]
+	evtIn          *StreamController[struct{}]
+	queue          *reactorQueue[T]
+	subscription   *Subscription
+	shutdownReason Data
+	eventRegister  map[interface{}][]*reactorHandler[T]
+	catchAll       []*reactorHandler[T]
+	middlewares    []Middleware[T]
+	clock          Clock
+	timers         *timerSet
 }
 
 // NewReactor creates a new Reactor.
 func NewReactor[T any]() (r *Reactor[T]) {
+	return NewReactorWithClock[T](RealClock{})
+}
+
+// NewReactorWithClock creates a new Reactor, which uses the given Clock for timed events.
+func NewReactorWithClock[T any](clock Clock) (r *Reactor[T]) {
+	return newReactor[T](clock, nil)
+}
+
+// NewReactorWithExecutor creates a new Reactor, which handles events and waits for timed events with the given
+// Executor instead of new go-routines.
+func NewReactorWithExecutor[T any](e Executor) (r *Reactor[T]) {
+	return newReactor[T](RealClock{}, e)
+}
 
+func newReactor[T any](clock Clock, e Executor) (r *Reactor[T]) {
 	r = &Reactor[T]{
 		Mutex:         new(sync.Mutex),
-		evtIn:         NewStreamController[Event[T]](),
-		eventRegister: map[interface{}]Subscriber[T]{},
+		evtIn:         NewStreamControllerWithExecutor[struct{}](e),
+		queue:         newReactorQueue[T](),
+		eventRegister: map[interface{}][]*reactorHandler[T]{},
+		clock:         clock,
+		timers:        newTimerSet(),
 	}
-	r.shutdownCompleter = r.evtIn.Stream().Listen(r.react)
+	r.subscription = r.evtIn.Stream().Listen(r.reactNext)
+	r.subscription.Done().Then(r.cancelTimers)
 	return
 }
 
@@ -36,6 +59,12 @@ func (r *Reactor[T]) OnShutdown(s Subscriber[Data]) {
 	r.React(ShutdownEvent{}, func(_ T) { s(r.shutdownReason) })
 }
 
+// OnPanic registers a PanicHandler, which gets invoked when an event handler panics. The reactor keeps on handling
+// events after a panic.
+func (r *Reactor[T]) OnPanic(h PanicHandler) {
+	r.evtIn.Stream().SetPanicHandler(h)
+}
+
 // Shutdown shuts down the reactor, cancelling all go routines and stream subscriptions. The error is to fullfill the `Shutdowner` interface and will always be nil.
 func (r *Reactor[T]) Shutdown(reason Data) (err error) {
 	r.shutdownReason = reason
@@ -46,45 +75,69 @@ func (r *Reactor[T]) Shutdown(reason Data) (err error) {
 
 // ShutdownFuture returns a future which gets completed after the reactor shut down.
 func (r *Reactor[T]) ShutdownFuture() *Future[Data] {
-	return r.shutdownCompleter.Future()
+	return r.subscription.Done()
 }
 
 func (r *Reactor[T]) shutdown() {
-	r.shutdownCompleter.Complete(nil)
+	for _, evt := range r.queue.close() {
+		if evt.reply != nil {
+			evt.reply(nil, ErrReactorShutdown)
+		}
+	}
+	r.subscription.Cancel()
+}
+
+func (r *Reactor[T]) cancelTimers(Data) {
+	r.timers.cancelAll()
 }
 
 // Fire triggers an event, invoking asynchronly the registered subscriber, if any. Events are guaranteed to be handled in the order of arrival.
 func (r *Reactor[T]) Fire(classifier interface{}, data T) {
-	if !r.shutdownCompleter.Completed() {
-		r.evtIn.Add(Event[T]{classifier, data})
+	if !r.subscription.Done().Completed() {
+		r.add(reactorEvent[T]{Event: Event[T]{Classifier: classifier, Data: data}})
 	}
 }
 
-// FireIn fires the given event after the given duration. FireIn can not be canceled.
-func (r *Reactor[T]) FireIn(classifier interface{}, data T, duration time.Duration) {
-	go r.fireIn(classifier, data, duration)
+// FireIn fires the given event after the given duration. Returns a Timer, which can be used to cancel or reschedule the
+// event.
+func (r *Reactor[T]) FireIn(classifier interface{}, data T, duration time.Duration) (t *Timer) {
+	t = r.newTimer(classifier, data, 0, 0)
+	t.start(duration)
+	return
 }
 
-func (r *Reactor[T]) fireIn(classifier interface{}, data T, d time.Duration) {
-	time.Sleep(d)
-	if r.shutdownCompleter.Completed() {
-		return
-	}
-	r.evtIn.Add(Event[T]{classifier, data})
+// FireAt fires the given event at the given time, measured with the Clock of the reactor. Returns a Timer, which can be
+// used to cancel or reschedule the event.
+func (r *Reactor[T]) FireAt(classifier interface{}, data T, at time.Time) (t *Timer) {
+	return r.FireIn(classifier, data, at.Sub(r.clock.Now()))
 }
 
-// FireEvery fires the given event repeatedly. FireEvery can not be canceled and will run until the reactor is shut down.
-func (r *Reactor[T]) FireEvery(classifier interface{}, data T, interval time.Duration) {
-	go r.fireEvery(classifier, data, interval)
+// FireEvery fires the given event repeatedly, until the returned Timer is cancelled or the reactor is shut down.
+func (r *Reactor[T]) FireEvery(classifier interface{}, data T, interval time.Duration) (t *Timer) {
+	return r.FireEveryWithJitter(classifier, data, interval, 0)
 }
 
-func (r *Reactor[T]) fireEvery(classifier interface{}, data T, d time.Duration) {
-	for {
-		time.Sleep(d)
-		if r.shutdownCompleter.Completed() {
-			return
-		}
-		r.evtIn.Add(Event[T]{classifier, data})
+// FireEveryWithJitter is the same as FireEvery, but every interval is extended by a random duration between zero and
+// jitter, to spread events of many timers.
+func (r *Reactor[T]) FireEveryWithJitter(classifier interface{}, data T, interval, jitter time.Duration) (t *Timer) {
+	t = r.newTimer(classifier, data, interval, jitter)
+	t.start(withJitter(interval, jitter))
+	return
+}
+
+// newTimer creates a timer, which waits on the clock and fires with the Executor of the reactor or, if none, in the
+// go-routine of the clock.
+func (r *Reactor[T]) newTimer(classifier interface{}, data T, interval, jitter time.Duration) *Timer {
+	execute := func(f func()) { f() }
+	if e := r.evtIn.Stream().executor; e != nil {
+		execute = e.Execute
+	}
+	return newTimer(r.clock, interval, jitter, r.fireTimed(classifier, data), execute, r.timers)
+}
+
+func (r *Reactor[T]) fireTimed(classifier interface{}, data T) func() {
+	return func() {
+		r.Fire(classifier, data)
 	}
 }
 
@@ -92,28 +145,114 @@ func (r *Reactor[T]) fireEvery(classifier interface{}, data T, d time.Duration)
 func (r *Reactor[T]) React(classifier interface{}, handler Subscriber[T]) {
 	r.Lock()
 	defer r.Unlock()
-	r.eventRegister[classifier] = handler
+	r.eventRegister[classifier] = []*reactorHandler[T]{{h: eventData(handler)}}
+}
+
+// AddHandler registers a Subscriber as additional handler for a given event classifier. Handlers of a classifier are
+// invoked in the order of their registration. Returns a Registration, which can be used to remove the handler.
+func (r *Reactor[T]) AddHandler(classifier interface{}, handler Subscriber[T]) (reg *Registration) {
+	r.Lock()
+	defer r.Unlock()
+	h := &reactorHandler[T]{h: eventData(handler)}
+	r.eventRegister[classifier] = append(r.eventRegister[classifier], h)
+	return newRegistration(r.removeHandler(classifier, h))
+}
+
+// AddCatchAll registers a handler, which gets invoked for all events without a handler registered for their classifier.
+// Returns a Registration, which can be used to remove the handler.
+func (r *Reactor[T]) AddCatchAll(handler func(Event[T])) (reg *Registration) {
+	r.Lock()
+	defer r.Unlock()
+	h := &reactorHandler[T]{h: wholeEvent(handler)}
+	r.catchAll = append(r.catchAll, h)
+	return newRegistration(r.removeCatchAll(h))
 }
 
-func (r *Reactor[T]) react(evt Event[T]) {
+// Classifiers returns all classifiers with at least one registered handler.
+func (r *Reactor[T]) Classifiers() (classifiers []interface{}) {
 	r.Lock()
 	defer r.Unlock()
-	if h, f := r.eventRegister[evt.Classifier]; f {
-		h(evt.Data)
+	for classifier := range r.eventRegister {
+		classifiers = append(classifiers, classifier)
 	}
+	return
+}
+
+// HandlerCount returns the number of handlers registered for the given classifier, not including catch-all handlers.
+func (r *Reactor[T]) HandlerCount(classifier interface{}) int {
+	r.Lock()
+	defer r.Unlock()
+	return len(r.eventRegister[classifier])
+}
+
+func (r *Reactor[T]) removeHandler(classifier interface{}, h *reactorHandler[T]) func() {
+	return func() {
+		r.Lock()
+		defer r.Unlock()
+		hs := removeReactorHandler(r.eventRegister[classifier], h)
+		if len(hs) == 0 {
+			delete(r.eventRegister, classifier)
+			return
+		}
+		r.eventRegister[classifier] = hs
+	}
+}
+
+func (r *Reactor[T]) removeCatchAll(h *reactorHandler[T]) func() {
+	return func() {
+		r.Lock()
+		defer r.Unlock()
+		r.catchAll = removeReactorHandler(r.catchAll, h)
+	}
+}
+
+func (r *Reactor[T]) reactNext(struct{}) {
+	if evt, ok := r.queue.pop(); ok {
+		r.react(evt)
+	}
+}
+
+// reactorEvent is an event queued on a reactor together with its dispatch state.
+type reactorEvent[T any] struct {
+	Event[T]
+	reply func(Data, error) // completes the Future of Ask, if not nil
+	stage int               // the middleware the event is dispatched to next
+}
+
+func (r *Reactor[T]) react(evt reactorEvent[T]) {
+	r.Lock()
+	defer r.Unlock()
 	if _, is := evt.Classifier.(ShutdownEvent); is {
-		r.shutdown()
+		defer r.shutdown()
+		r.handle(evt)
+		return
+	}
+	r.dispatch(evt)
+}
+
+// handle invokes the handlers registered for the event. Must be called with lock held.
+func (r *Reactor[T]) handle(evt reactorEvent[T]) {
+	if evt.reply != nil {
+		// fails the request, if no handler replied
+		defer evt.reply(nil, ErrNoReply)
+	}
+	hs, f := r.eventRegister[evt.Classifier]
+	if _, shutdown := evt.Classifier.(ShutdownEvent); !f && !shutdown {
+		hs = r.catchAll
+	}
+	for _, h := range hs {
+		h.h(evt)
 	}
 }
 
 // AddStream subscribes to a Stream, firing an event with the given classifier for every new element in the stream.
 func (r *Reactor[T]) AddStream(classifier interface{}, s *Stream[T]) {
-	s.Listen(r.createEventFromStream(classifier)).CompleteOnFuture(r.shutdownCompleter.Future())
+	s.Listen(r.createEventFromStream(classifier)).CancelOnFuture(r.subscription.Done())
 }
 
 func (r *Reactor[T]) createEventFromStream(classifier interface{}) Subscriber[T] {
 	return func(d T) {
-		r.evtIn.Add(Event[T]{classifier, d})
+		r.add(reactorEvent[T]{Event: Event[T]{Classifier: classifier, Data: d}})
 	}
 }
 
@@ -156,6 +295,6 @@ func (r *Reactor[T]) waitForCtrlC() {
 	select {
 	case s := <-c:
 		r.Shutdown(s)
-	case <-r.shutdownCompleter.Future().AsChan():
+	case <-r.subscription.Done().AsChan():
 	}
 }
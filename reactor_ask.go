@@ -0,0 +1,76 @@
+package eventual2go
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrNoReply represents the error of a request made with Ask, which was not replied by a handler registered with
+// ReactReply.
+var ErrNoReply = errors.New("No reply")
+
+// ErrReactorShutdown represents the error of a request made with Ask on a reactor, which is shut down.
+var ErrReactorShutdown = errors.New("Reactor shut down")
+
+// ErrWrongReplyType represents the error of a request made with Ask, which was replied with a result of another type.
+var ErrWrongReplyType = errors.New("Wrong reply type")
+
+// Ask fires an event and returns a Future, which gets completed with the result of the handler registered with
+// ReactReply for the classifier, or fails with its error. The handler runs under the reactor's lock like any other
+// handler. If no handler replies, the Future fails with ErrNoReply. If the reactor shuts down before the request is
+// handled, the Future fails with ErrReactorShutdown.
+func Ask[T, R any](r *Reactor[T], classifier interface{}, data T) (f *Future[R]) {
+	return ask(r, classifier, data, NewCompleter[R]())
+}
+
+// AskTimeout is the same as Ask, but the Future fails with ErrTimeout, if the request isn't replied within the given
+// duration, measured with the Clock of the reactor.
+func AskTimeout[T, R any](r *Reactor[T], classifier interface{}, data T, timeout time.Duration) (f *Future[R]) {
+	return ask(r, classifier, data, NewTimeoutCompleterWithClock[R](timeout, r.clock))
+}
+
+func ask[T, R any](r *Reactor[T], classifier interface{}, data T, c *Completer[R]) (f *Future[R]) {
+	f = c.Future()
+	if !r.add(reactorEvent[T]{Event: Event[T]{Classifier: classifier, Data: data}, reply: reply(c)}) {
+		c.TryCompleteError(ErrReactorShutdown)
+	}
+	return
+}
+
+func reply[R any](c *Completer[R]) func(Data, error) {
+	return func(d Data, err error) {
+		if err != nil {
+			c.TryCompleteError(err)
+			return
+		}
+		if d == nil {
+			var res R
+			c.TryComplete(res)
+			return
+		}
+		res, ok := d.(R)
+		if !ok {
+			c.TryCompleteError(ErrWrongReplyType)
+			return
+		}
+		c.TryComplete(res)
+	}
+}
+
+// ReactReply registers a handler for a given event classifier, which result or error is the reply to requests made
+// with Ask. Events fired with Fire invoke the handler as well, the reply is discarded then. Previously registered
+// handlers for the given classifier will be overwritten!
+func ReactReply[T, R any](r *Reactor[T], classifier interface{}, h func(T) (R, error)) {
+	r.Lock()
+	defer r.Unlock()
+	r.eventRegister[classifier] = []*reactorHandler[T]{{h: replyWith(h)}}
+}
+
+func replyWith[T, R any](h func(T) (R, error)) func(reactorEvent[T]) {
+	return func(evt reactorEvent[T]) {
+		res, err := h(evt.Data)
+		if evt.reply != nil {
+			evt.reply(res, err)
+		}
+	}
+}
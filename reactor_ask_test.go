@@ -0,0 +1,115 @@
+package eventual2go
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestReactorAsk(t *testing.T) {
+	r := NewReactor[int]()
+	defer r.Shutdown(nil)
+	counter := 0
+	ReactReply(r, "inc", func(d int) (int, error) {
+		counter += d
+		return counter, nil
+	})
+
+	Ask[int, int](r, "inc", 1)
+	f := Ask[int, int](r, "inc", 2)
+	if res, err := f.GetTimeout(10 * time.Millisecond); res != 3 || err != nil {
+		t.Error("got wrong reply", res, err)
+	}
+}
+
+func TestReactorAskError(t *testing.T) {
+	r := NewReactor[int]()
+	defer r.Shutdown(nil)
+	ReactReply(r, "fail", func(int) (string, error) {
+		return "", errors.New("testerror")
+	})
+
+	f := Ask[int, string](r, "fail", 0)
+	if _, err := f.GetTimeout(10 * time.Millisecond); err == nil || err.Error() != "testerror" {
+		t.Error("got wrong error", err)
+	}
+}
+
+func TestReactorAskNoReply(t *testing.T) {
+	r := NewReactor[int]()
+	defer r.Shutdown(nil)
+	r.React("noreply", func(int) {})
+
+	for _, classifier := range []string{"noreply", "unregistered"} {
+		f := Ask[int, int](r, classifier, 0)
+		if _, err := f.GetTimeout(10 * time.Millisecond); err != ErrNoReply {
+			t.Error("got wrong error", err)
+		}
+	}
+}
+
+func TestReactorAskWrongType(t *testing.T) {
+	r := NewReactor[int]()
+	defer r.Shutdown(nil)
+	ReactReply(r, "test", func(d int) (int, error) {
+		return d, nil
+	})
+
+	f := Ask[int, string](r, "test", 0)
+	if _, err := f.GetTimeout(10 * time.Millisecond); err != ErrWrongReplyType {
+		t.Error("got wrong error", err)
+	}
+}
+
+func TestReactorAskTimeout(t *testing.T) {
+	mc := NewManualClock(time.Unix(0, 0))
+	r := NewReactorWithClock[int](mc)
+	defer r.Shutdown(nil)
+	release := make(chan struct{})
+	defer close(release)
+	ReactReply(r, "slow", func(d int) (int, error) {
+		<-release
+		return d, nil
+	})
+
+	f := AskTimeout[int, int](r, "slow", 0, 1*time.Second)
+	mc.BlockUntil(1)
+	mc.Advance(1 * time.Second)
+	if _, err := f.GetTimeout(10 * time.Millisecond); err != ErrTimeout {
+		t.Error("got wrong error", err)
+	}
+}
+
+func TestReactorAskAfterShutdown(t *testing.T) {
+	r := NewReactor[int]()
+	r.Shutdown(nil)
+	r.ShutdownFuture().WaitUntilTimeout(10 * time.Millisecond)
+
+	f := Ask[int, int](r, "test", 0)
+	if _, err := f.GetTimeout(10 * time.Millisecond); err != ErrReactorShutdown {
+		t.Error("got wrong error", err)
+	}
+}
+
+func TestReactorAskQueuedBehindShutdown(t *testing.T) {
+	r := NewReactor[int]()
+	started := make(chan struct{})
+	release := make(chan struct{})
+	r.React("block", func(int) {
+		close(started)
+		<-release
+	})
+	ReactReply(r, "test", func(d int) (int, error) {
+		return d, nil
+	})
+
+	r.Fire("block", 0)
+	<-started
+	r.Shutdown(nil)
+	f := Ask[int, int](r, "test", 0)
+	close(release)
+
+	if _, err := f.GetTimeout(10 * time.Millisecond); err != ErrReactorShutdown {
+		t.Error("got wrong error", err)
+	}
+}
@@ -0,0 +1,45 @@
+package eventual2go
+
+import "sync"
+
+// A Registration represents a handler registered on a Reactor.
+type Registration struct {
+	once   sync.Once
+	remove func()
+}
+
+func newRegistration(remove func()) *Registration {
+	return &Registration{remove: remove}
+}
+
+// Remove unregisters the handler. Removing an already removed handler has no effect. Like registering handlers,
+// removing must not be done from within a handler of the same Reactor, since handlers are invoked with the Reactor
+// locked.
+func (reg *Registration) Remove() {
+	reg.once.Do(reg.remove)
+}
+
+type reactorHandler[T any] struct {
+	h func(reactorEvent[T])
+}
+
+func eventData[T any](s Subscriber[T]) func(reactorEvent[T]) {
+	return func(evt reactorEvent[T]) {
+		s(evt.Data)
+	}
+}
+
+func wholeEvent[T any](h func(Event[T])) func(reactorEvent[T]) {
+	return func(evt reactorEvent[T]) {
+		h(evt.Event)
+	}
+}
+
+func removeReactorHandler[T any](hs []*reactorHandler[T], h *reactorHandler[T]) []*reactorHandler[T] {
+	for i, hh := range hs {
+		if hh == h {
+			return append(hs[:i:i], hs[i+1:]...)
+		}
+	}
+	return hs
+}
@@ -0,0 +1,115 @@
+package eventual2go
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func receiveStrings(t *testing.T, c chan string, want ...string) {
+	t.Helper()
+	for _, w := range want {
+		select {
+		case got := <-c:
+			if got != w {
+				t.Fatal("got wrong data", got, "want", w)
+			}
+		case <-time.After(10 * time.Millisecond):
+			t.Fatal("no response")
+		}
+	}
+	select {
+	case got := <-c:
+		t.Fatal("got unexpected data", got)
+	case <-time.After(1 * time.Millisecond):
+	}
+}
+
+func TestReactorAddHandler(t *testing.T) {
+	r := NewReactor[string]()
+	defer r.Shutdown(nil)
+	c := make(chan string, 10)
+	first := r.AddHandler("test", func(d string) {
+		c <- "first " + d
+	})
+	r.AddHandler("test", func(d string) {
+		c <- "second " + d
+	})
+	if r.HandlerCount("test") != 2 {
+		t.Error("wrong handler count", r.HandlerCount("test"))
+	}
+
+	r.Fire("test", "a")
+	receiveStrings(t, c, "first a", "second a")
+
+	first.Remove()
+	first.Remove()
+	r.Fire("test", "b")
+	receiveStrings(t, c, "second b")
+}
+
+func TestReactorReactOverwritesHandlers(t *testing.T) {
+	r := NewReactor[string]()
+	defer r.Shutdown(nil)
+	c := make(chan string, 10)
+	r.AddHandler("test", func(d string) {
+		c <- "added"
+	})
+	r.React("test", func(d string) {
+		c <- "react"
+	})
+
+	r.Fire("test", "")
+	receiveStrings(t, c, "react")
+}
+
+func TestReactorCatchAll(t *testing.T) {
+	r := NewReactor[string]()
+	defer r.Shutdown(nil)
+	c := make(chan string, 10)
+	r.React("test", func(d string) {
+		c <- d
+	})
+	reg := r.AddCatchAll(func(evt Event[string]) {
+		c <- evt.Classifier.(string) + " " + evt.Data
+	})
+
+	r.Fire("test", "a")
+	r.Fire("other", "b")
+	receiveStrings(t, c, "a", "other b")
+
+	reg.Remove()
+	r.Fire("other", "c")
+	receiveStrings(t, c)
+}
+
+func TestReactorCatchAllIgnoresShutdown(t *testing.T) {
+	r := NewReactor[string]()
+	c := make(chan string, 10)
+	r.AddCatchAll(func(evt Event[string]) {
+		c <- fmt.Sprint(evt.Classifier)
+	})
+
+	r.Shutdown(nil)
+	if !r.ShutdownFuture().WaitUntilTimeout(10 * time.Millisecond) {
+		t.Fatal("reactor didn't shut down")
+	}
+	receiveStrings(t, c)
+}
+
+func TestReactorClassifiers(t *testing.T) {
+	r := NewReactor[string]()
+	defer r.Shutdown(nil)
+	reg := r.AddHandler("test", func(string) {})
+
+	if cs := r.Classifiers(); len(cs) != 1 || cs[0] != "test" {
+		t.Error("wrong classifiers", cs)
+	}
+	reg.Remove()
+	if cs := r.Classifiers(); len(cs) != 0 {
+		t.Error("wrong classifiers", cs)
+	}
+	if r.HandlerCount("test") != 0 {
+		t.Error("handler wasn't removed")
+	}
+}
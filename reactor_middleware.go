@@ -0,0 +1,66 @@
+package eventual2go
+
+import "sync"
+
+// A Middleware wraps the dispatch of every event of a Reactor. It passes the event, which it may modify, on by calling
+// next, or discards it by calling drop. Calling next after the Middleware returned delays the event, it is then
+// queued again and passed to the next Middleware in the order of arrival. Calling next from another go-routine
+// before the Middleware returned is not allowed. Middlewares run under the reactor's lock like handlers.
+type Middleware[T any] func(evt Event[T], next func(Event[T]), drop func())
+
+// Use adds a Middleware. Middlewares are invoked in the order they were added, for events of all sources. The
+// ShutdownEvent bypasses the middlewares, so the reactor can always be shut down. Requests made with Ask, which a
+// Middleware drops, fail with ErrNoReply. A Middleware returning without calling next or drop must call one of them
+// later, otherwise such requests are never replied.
+func (r *Reactor[T]) Use(m Middleware[T]) {
+	r.Lock()
+	defer r.Unlock()
+	r.middlewares = append(r.middlewares, m)
+}
+
+// dispatch passes the event to the middleware at its stage or, after the last one, to the handlers. Must be called
+// with lock held.
+func (r *Reactor[T]) dispatch(evt reactorEvent[T]) {
+	if evt.stage >= len(r.middlewares) {
+		r.handle(evt)
+		return
+	}
+	n := &middlewareNext[T]{
+		r:     r,
+		reply: evt.reply,
+		stage: evt.stage + 1,
+		sync:  true,
+	}
+	r.middlewares[evt.stage](evt.Event, n.call, n.drop)
+	n.m.Lock()
+	n.sync = false
+	n.m.Unlock()
+}
+
+type middlewareNext[T any] struct {
+	m     sync.Mutex
+	r     *Reactor[T]
+	reply func(Data, error)
+	stage int
+	sync  bool // TRUE as long as the middleware didn't return
+}
+
+func (n *middlewareNext[T]) call(e Event[T]) {
+	n.m.Lock()
+	sync := n.sync
+	n.m.Unlock()
+	evt := reactorEvent[T]{Event: e, reply: n.reply, stage: n.stage}
+	if sync {
+		n.r.dispatch(evt)
+		return
+	}
+	if !n.r.add(evt) && evt.reply != nil {
+		evt.reply(nil, ErrReactorShutdown)
+	}
+}
+
+func (n *middlewareNext[T]) drop() {
+	if n.reply != nil {
+		n.reply(nil, ErrNoReply)
+	}
+}
@@ -0,0 +1,128 @@
+package eventual2go
+
+import (
+	"testing"
+	"time"
+)
+
+func TestReactorMiddlewareOrder(t *testing.T) {
+	r := NewReactor[string]()
+	defer r.Shutdown(nil)
+	c := make(chan string, 10)
+	r.Use(func(evt Event[string], next func(Event[string]), drop func()) {
+		c <- "first"
+		evt.Data += "!"
+		next(evt)
+	})
+	r.Use(func(evt Event[string], next func(Event[string]), drop func()) {
+		c <- "second"
+		next(evt)
+	})
+	r.React("test", func(d string) {
+		c <- d
+	})
+
+	r.Fire("test", "a")
+	receiveStrings(t, c, "first", "second", "a!")
+}
+
+func TestReactorMiddlewareDrop(t *testing.T) {
+	r := NewReactor[string]()
+	defer r.Shutdown(nil)
+	c := make(chan string, 10)
+	r.Use(func(evt Event[string], next func(Event[string]), drop func()) {
+		if evt.Data == "drop" {
+			drop()
+			return
+		}
+		next(evt)
+	})
+	r.React("test", func(d string) {
+		c <- d
+	})
+	sc := NewStreamController[string]()
+	r.AddStream("test", sc.Stream())
+
+	sc.Add("drop")
+	sc.Add("a")
+	r.Fire("test", "drop")
+	receiveStrings(t, c, "a")
+}
+
+func TestReactorMiddlewareDelay(t *testing.T) {
+	r := NewReactor[string]()
+	defer r.Shutdown(nil)
+	c := make(chan string, 10)
+	r.Use(func(evt Event[string], next func(Event[string]), drop func()) {
+		if evt.Data == "delayed" {
+			time.AfterFunc(2*time.Millisecond, func() { next(evt) })
+			return
+		}
+		next(evt)
+	})
+	r.Use(func(evt Event[string], next func(Event[string]), drop func()) {
+		c <- "second " + evt.Data
+		next(evt)
+	})
+	r.React("test", func(d string) {
+		c <- d
+	})
+
+	r.Fire("test", "delayed")
+	r.Fire("test", "a")
+	receiveStrings(t, c, "second a", "a", "second delayed", "delayed")
+}
+
+func TestReactorMiddlewareShutdown(t *testing.T) {
+	r := NewReactor[string]()
+	r.Use(func(_ Event[string], _ func(Event[string]), drop func()) { drop() })
+	r.Shutdown(nil)
+	if !r.ShutdownFuture().WaitUntilTimeout(10 * time.Millisecond) {
+		t.Error("reactor didn't shut down")
+	}
+}
+
+func TestReactorMiddlewareNewEventKeepsReply(t *testing.T) {
+	r := NewReactor[string]()
+	defer r.Shutdown(nil)
+	r.Use(func(evt Event[string], next func(Event[string]), drop func()) {
+		next(Event[string]{evt.Classifier, evt.Data + "!"})
+	})
+	ReactReply(r, "test", func(d string) (string, error) {
+		return d, nil
+	})
+
+	res, err := Ask[string, string](r, "test", "a").GetTimeout(10 * time.Millisecond)
+	if err != nil || res != "a!" {
+		t.Error("got wrong reply", res, err)
+	}
+}
+
+func TestReactorMiddlewareDropAsk(t *testing.T) {
+	r := NewReactor[string]()
+	defer r.Shutdown(nil)
+	r.Use(func(_ Event[string], _ func(Event[string]), drop func()) { drop() })
+	ReactReply(r, "test", func(d string) (string, error) {
+		return d, nil
+	})
+
+	if _, err := Ask[string, string](r, "test", "a").GetTimeout(10 * time.Millisecond); err != ErrNoReply {
+		t.Error("wrong error", err)
+	}
+}
+
+func TestReactorMiddlewareDelayAsk(t *testing.T) {
+	r := NewReactor[string]()
+	defer r.Shutdown(nil)
+	r.Use(func(evt Event[string], next func(Event[string]), drop func()) {
+		time.AfterFunc(time.Millisecond, func() { next(evt) })
+	})
+	ReactReply(r, "test", func(d string) (string, error) {
+		return d, nil
+	})
+
+	res, err := Ask[string, string](r, "test", "a").GetTimeout(10 * time.Millisecond)
+	if err != nil || res != "a" {
+		t.Error("got wrong reply", res, err)
+	}
+}
@@ -0,0 +1,128 @@
+package eventual2go
+
+import (
+	"container/heap"
+	"sync"
+)
+
+// SetPriority sets the priority of events with the given classifier. Events with a higher priority are handled before
+// queued events with a lower one, events of the same priority in the order of arrival. The default priority is 0.
+// To let the reactor shut down without handling queued events first, set a high priority for the ShutdownEvent.
+func (r *Reactor[T]) SetPriority(classifier interface{}, priority int) {
+	r.queue.setPriority(classifier, priority)
+}
+
+// FireWithPriority is the same as Fire, but the event is handled with the given priority instead of the one set for
+// the classifier.
+func (r *Reactor[T]) FireWithPriority(classifier interface{}, data T, priority int) {
+	if r.queue.push(reactorEvent[T]{Event: Event[T]{Classifier: classifier, Data: data}}, priority) {
+		r.evtIn.Add(struct{}{})
+	}
+}
+
+// add queues the event with the priority of its classifier. Every queued event is paired with an element of evtIn, so
+// the reactor handles the event with the highest priority for every element. Returns false, if the reactor is shut
+// down.
+func (r *Reactor[T]) add(evt reactorEvent[T]) (ok bool) {
+	if !r.queue.push(evt, r.queue.priority(evt.Classifier)) {
+		return
+	}
+	r.evtIn.Add(struct{}{})
+	return true
+}
+
+type reactorQueue[T any] struct {
+	m          *sync.Mutex
+	events     prioritizedEvents[T]
+	priorities map[interface{}]int
+	seq        uint64
+	closed     bool
+}
+
+func newReactorQueue[T any]() *reactorQueue[T] {
+	return &reactorQueue[T]{
+		m:          &sync.Mutex{},
+		priorities: map[interface{}]int{},
+	}
+}
+
+func (q *reactorQueue[T]) setPriority(classifier interface{}, priority int) {
+	q.m.Lock()
+	defer q.m.Unlock()
+	q.priorities[classifier] = priority
+}
+
+func (q *reactorQueue[T]) priority(classifier interface{}) int {
+	q.m.Lock()
+	defer q.m.Unlock()
+	return q.priorities[classifier]
+}
+
+func (q *reactorQueue[T]) push(evt reactorEvent[T], priority int) (ok bool) {
+	q.m.Lock()
+	defer q.m.Unlock()
+	if q.closed {
+		return
+	}
+	q.seq++
+	heap.Push(&q.events, prioritizedEvent[T]{evt, priority, q.seq})
+	return true
+}
+
+func (q *reactorQueue[T]) pop() (evt reactorEvent[T], ok bool) {
+	q.m.Lock()
+	defer q.m.Unlock()
+	if len(q.events) == 0 {
+		return
+	}
+	return heap.Pop(&q.events).(prioritizedEvent[T]).evt, true
+}
+
+// close rejects further events and returns the queued ones.
+func (q *reactorQueue[T]) close() (evts []reactorEvent[T]) {
+	q.m.Lock()
+	defer q.m.Unlock()
+	q.closed = true
+	for _, pe := range q.events {
+		evts = append(evts, pe.evt)
+	}
+	q.events = nil
+	return
+}
+
+type prioritizedEvent[T any] struct {
+	evt      reactorEvent[T]
+	priority int
+	seq      uint64
+}
+
+// prioritizedEvents implements heap.Interface.
+type prioritizedEvents[T any] []prioritizedEvent[T]
+
+func (pe prioritizedEvents[T]) Len() int {
+	return len(pe)
+}
+
+func (pe prioritizedEvents[T]) Less(i, j int) bool {
+	if pe[i].priority != pe[j].priority {
+		return pe[i].priority > pe[j].priority
+	}
+	return pe[i].seq < pe[j].seq
+}
+
+func (pe prioritizedEvents[T]) Swap(i, j int) {
+	pe[i], pe[j] = pe[j], pe[i]
+}
+
+func (pe *prioritizedEvents[T]) Push(x interface{}) {
+	*pe = append(*pe, x.(prioritizedEvent[T]))
+}
+
+func (pe *prioritizedEvents[T]) Pop() interface{} {
+	old := *pe
+	n := len(old)
+	x := old[n-1]
+	old[n-1] = prioritizedEvent[T]{}
+	*pe = old[:n-1]
+	return x
+}
@@ -0,0 +1,55 @@
+package eventual2go
+
+import (
+	"testing"
+	"time"
+)
+
+// blockReactor fires an event which handler blocks until release is closed.
+func blockReactor(r *Reactor[string], release chan struct{}) {
+	started := make(chan struct{})
+	r.React("block", func(string) {
+		close(started)
+		<-release
+	})
+	r.Fire("block", "")
+	<-started
+}
+
+func TestReactorPriority(t *testing.T) {
+	r := NewReactor[string]()
+	defer r.Shutdown(nil)
+	c := make(chan string, 10)
+	r.SetPriority("urgent", 10)
+	r.React("data", func(d string) { c <- d })
+	r.React("urgent", func(d string) { c <- d })
+	release := make(chan struct{})
+	blockReactor(r, release)
+
+	r.Fire("data", "a")
+	r.Fire("data", "b")
+	r.Fire("urgent", "u1")
+	r.FireWithPriority("data", "c", 5)
+	r.Fire("urgent", "u2")
+	close(release)
+
+	receiveStrings(t, c, "u1", "u2", "c", "a", "b")
+}
+
+func TestReactorShutdownPriority(t *testing.T) {
+	r := NewReactor[string]()
+	c := make(chan string, 10)
+	r.SetPriority(ShutdownEvent{}, 100)
+	r.React("data", func(d string) { c <- d })
+	release := make(chan struct{})
+	blockReactor(r, release)
+
+	r.Fire("data", "a")
+	r.Shutdown(nil)
+	close(release)
+
+	if !r.ShutdownFuture().WaitUntilTimeout(10 * time.Millisecond) {
+		t.Fatal("reactor didn't shut down")
+	}
+	receiveStrings(t, c)
+}
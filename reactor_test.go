@@ -86,6 +86,47 @@ func TestReactorStream(t *testing.T) {
 	}
 }
 
+func TestReactorFireInWithClock(t *testing.T) {
+	mc := NewManualClock(time.Unix(0, 0))
+	r := NewReactorWithClock[Data](mc)
+	defer r.Shutdown(nil)
+	rt := &reactorTester{Mutex: &sync.Mutex{}}
+	r.React("TestEvent", rt.Handler)
+
+	r.FireIn("TestEvent", "HALLO", 1*time.Second)
+	mc.BlockUntil(1)
+	mc.Advance(999 * time.Millisecond)
+	time.Sleep(1 * time.Millisecond)
+	if rt.hasFired() {
+		t.Fatal("Event fired to early")
+	}
+
+	mc.Advance(1 * time.Millisecond)
+	time.Sleep(1 * time.Millisecond)
+	if !rt.hasFired() {
+		t.Fatal("Event didnt fire")
+	}
+}
+
+func TestReactorFireEveryWithClock(t *testing.T) {
+	mc := NewManualClock(time.Unix(0, 0))
+	r := NewReactorWithClock[int](mc)
+	defer r.Shutdown(nil)
+	c := make(chan int, 10)
+	r.React("TestEvent", func(d int) { c <- d })
+
+	r.FireEvery("TestEvent", 1, 1*time.Second)
+	for i := 0; i < 3; i++ {
+		mc.BlockUntil(1)
+		mc.Advance(1 * time.Second)
+		select {
+		case <-c:
+		case <-time.After(10 * time.Millisecond):
+			t.Fatal("Event didnt fire")
+		}
+	}
+}
+
 type reactorTester struct {
 	*sync.Mutex
 	evtFired bool
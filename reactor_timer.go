@@ -0,0 +1,170 @@
+package eventual2go
+
+import (
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// A Timer represents an event scheduled on a Reactor with FireIn, FireAt or FireEvery.
+type Timer struct {
+	m        *sync.Mutex
+	clock    Clock
+	t        ClockTimer
+	gen      int // identifies the current schedule, as stopping doesn't prevent a started callback
+	interval time.Duration
+	jitter   time.Duration
+	fire     func()
+	execute  func(func())
+	done     *Completer[Data]
+	timers   *timerSet
+}
+
+func newTimer(clock Clock, interval, jitter time.Duration, fire func(), execute func(func()), timers *timerSet) *Timer {
+	return &Timer{
+		m:        &sync.Mutex{},
+		clock:    clock,
+		interval: interval,
+		jitter:   jitter,
+		fire:     fire,
+		execute:  execute,
+		done:     NewCompleter[Data](),
+		timers:   timers,
+	}
+}
+
+// start schedules the first event, unless the reactor is already shut down.
+func (t *Timer) start(d time.Duration) {
+	if !t.timers.add(t) {
+		t.Cancel()
+		return
+	}
+	t.m.Lock()
+	defer t.m.Unlock()
+	if !t.done.Completed() {
+		t.schedule(d)
+	}
+}
+
+// Cancel stops the timer, the event will not be fired anymore. Cancelling an already stopped timer has no effect.
+func (t *Timer) Cancel() {
+	if !t.done.TryComplete(nil) {
+		return
+	}
+	t.m.Lock()
+	if t.t != nil {
+		t.t.Stop()
+	}
+	t.m.Unlock()
+	t.timers.remove(t)
+}
+
+// Reset reschedules the next event to be fired after the given duration. A periodic timer continues with its interval
+// afterwards. Resetting a stopped timer has no effect.
+func (t *Timer) Reset(d time.Duration) {
+	t.m.Lock()
+	defer t.m.Unlock()
+	if t.done.Completed() {
+		return
+	}
+	t.schedule(d)
+}
+
+// SetInterval changes the interval of a timer started with FireEvery and reschedules the next event to be fired after
+// the new interval. Has no effect on timers started with FireIn or FireAt.
+func (t *Timer) SetInterval(interval time.Duration) {
+	t.m.Lock()
+	defer t.m.Unlock()
+	if t.interval <= 0 || t.done.Completed() {
+		return
+	}
+	t.interval = interval
+	t.schedule(withJitter(interval, t.jitter))
+}
+
+// Done returns a Future which completes when the timer got cancelled, fired its last event or the reactor shut down.
+func (t *Timer) Done() *Future[Data] {
+	return t.done.Future()
+}
+
+// schedule replaces the current ClockTimer. Must be called with lock held.
+func (t *Timer) schedule(d time.Duration) {
+	if t.t != nil {
+		t.t.Stop()
+	}
+	t.gen++
+	gen := t.gen
+	t.t = t.clock.AfterFunc(d, func() { t.expire(gen) })
+}
+
+// expire is called by the ClockTimer and passes the event to the executor of the reactor.
+func (t *Timer) expire(gen int) {
+	t.m.Lock()
+	if gen != t.gen || t.done.Completed() {
+		t.m.Unlock()
+		return
+	}
+	last := t.interval <= 0
+	if !last {
+		t.schedule(withJitter(t.interval, t.jitter))
+	}
+	t.m.Unlock()
+
+	t.execute(func() {
+		if t.done.Completed() {
+			return
+		}
+		t.fire()
+		if last {
+			t.Cancel()
+		}
+	})
+}
+
+func withJitter(d, jitter time.Duration) time.Duration {
+	if jitter <= 0 {
+		return d
+	}
+	return d + time.Duration(rand.Int63n(int64(jitter)))
+}
+
+// timerSet tracks the pending timers of a reactor, so they can be cancelled on shutdown.
+type timerSet struct {
+	m      sync.Mutex
+	timers map[*Timer]struct{}
+	closed bool
+}
+
+func newTimerSet() *timerSet {
+	return &timerSet{timers: map[*Timer]struct{}{}}
+}
+
+func (ts *timerSet) add(t *Timer) (ok bool) {
+	ts.m.Lock()
+	defer ts.m.Unlock()
+	if ts.closed {
+		return
+	}
+	ts.timers[t] = struct{}{}
+	return true
+}
+
+func (ts *timerSet) remove(t *Timer) {
+	ts.m.Lock()
+	defer ts.m.Unlock()
+	delete(ts.timers, t)
+}
+
+// cancelAll cancels all pending timers and cancels timers added later immediately.
+func (ts *timerSet) cancelAll() {
+	ts.m.Lock()
+	ts.closed = true
+	var timers []*Timer
+	for t := range ts.timers {
+		timers = append(timers, t)
+	}
+	ts.m.Unlock()
+	for _, t := range timers {
+		t.Cancel()
+	}
+}
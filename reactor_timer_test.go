@@ -0,0 +1,137 @@
+package eventual2go
+
+import (
+	"testing"
+	"time"
+)
+
+func expectFired(t *testing.T, c chan int, fired bool) {
+	t.Helper()
+	select {
+	case <-c:
+		if !fired {
+			t.Fatal("event fired")
+		}
+	case <-time.After(5 * time.Millisecond):
+		if fired {
+			t.Fatal("event didn't fire")
+		}
+	}
+}
+
+func newTimerReactor() (*Reactor[int], *ManualClock, chan int) {
+	mc := NewManualClock(time.Unix(0, 0))
+	r := NewReactorWithClock[int](mc)
+	c := make(chan int, 10)
+	r.React("TestEvent", func(d int) { c <- d })
+	return r, mc, c
+}
+
+func TestTimerCancel(t *testing.T) {
+	r, mc, c := newTimerReactor()
+	defer r.Shutdown(nil)
+
+	timer := r.FireIn("TestEvent", 1, 1*time.Second)
+	timer.Cancel()
+	timer.Cancel()
+	if !timer.Done().WaitUntilTimeout(10 * time.Millisecond) {
+		t.Fatal("timer didn't stop")
+	}
+	mc.Advance(1 * time.Second)
+	expectFired(t, c, false)
+	if mc.Waiters() != 0 {
+		t.Error("timer is still waiting")
+	}
+}
+
+func TestTimerReset(t *testing.T) {
+	r, mc, c := newTimerReactor()
+	defer r.Shutdown(nil)
+
+	timer := r.FireIn("TestEvent", 1, 1*time.Second)
+	timer.Reset(2 * time.Second)
+	mc.Advance(1 * time.Second)
+	expectFired(t, c, false)
+	mc.Advance(1 * time.Second)
+	expectFired(t, c, true)
+	if !timer.Done().WaitUntilTimeout(10 * time.Millisecond) {
+		t.Error("timer didn't stop after firing")
+	}
+}
+
+func TestTimerSetInterval(t *testing.T) {
+	r, mc, c := newTimerReactor()
+	defer r.Shutdown(nil)
+
+	timer := r.FireEvery("TestEvent", 1, 1*time.Second)
+	mc.Advance(1 * time.Second)
+	expectFired(t, c, true)
+
+	timer.SetInterval(3 * time.Second)
+	mc.Advance(2 * time.Second)
+	expectFired(t, c, false)
+	mc.Advance(1 * time.Second)
+	expectFired(t, c, true)
+	mc.BlockUntil(1)
+	mc.Advance(3 * time.Second)
+	expectFired(t, c, true)
+
+	timer.Cancel()
+	if !timer.Done().WaitUntilTimeout(10 * time.Millisecond) {
+		t.Fatal("timer didn't stop")
+	}
+	mc.Advance(3 * time.Second)
+	expectFired(t, c, false)
+}
+
+func TestTimerFireAt(t *testing.T) {
+	r, mc, c := newTimerReactor()
+	defer r.Shutdown(nil)
+
+	r.FireAt("TestEvent", 1, time.Unix(10, 0))
+	mc.Advance(9 * time.Second)
+	expectFired(t, c, false)
+	mc.Advance(1 * time.Second)
+	expectFired(t, c, true)
+}
+
+func TestTimerJitter(t *testing.T) {
+	r, mc, c := newTimerReactor()
+	defer r.Shutdown(nil)
+
+	r.FireEveryWithJitter("TestEvent", 1, 1*time.Second, 500*time.Millisecond)
+	for i := 0; i < 3; i++ {
+		mc.BlockUntil(1)
+		mc.Advance(999 * time.Millisecond)
+		expectFired(t, c, false)
+		mc.Advance(501 * time.Millisecond)
+		expectFired(t, c, true)
+		mc.BlockUntil(1)
+		// realign the clock to the last event
+		mc.Advance(1 * time.Millisecond)
+	}
+}
+
+func TestTimerStopsOnShutdown(t *testing.T) {
+	r, _, _ := newTimerReactor()
+	timer := r.FireEvery("TestEvent", 1, 1*time.Second)
+	r.Shutdown(nil)
+	if !timer.Done().WaitUntilTimeout(10 * time.Millisecond) {
+		t.Error("timer didn't stop on shutdown")
+	}
+}
+
+func TestTimersDontOccupyWorkers(t *testing.T) {
+	wp := NewWorkerPool(1)
+	defer wp.Shutdown(nil)
+	r := NewReactorWithExecutor[int](wp)
+	defer r.Shutdown(nil)
+	c := make(chan int, 1)
+	r.React("TestEvent", func(d int) { c <- d })
+
+	for i := 0; i < 3; i++ {
+		r.FireIn("Pending", 0, 1*time.Hour)
+	}
+	r.Fire("TestEvent", 1)
+	expectFired(t, c, true)
+}
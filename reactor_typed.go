@@ -0,0 +1,23 @@
+package eventual2go
+
+// typeKey is the classifier of typed events with payload type E.
+type typeKey[E any] struct{}
+
+// RegisterHandler registers a handler for events of type E, which are fired with Emit. Events of different types are
+// handled by the same reactor in the order of arrival. Previously registered handlers for type E will be overwritten!
+func RegisterHandler[E any](r *Reactor[Data], h func(E)) {
+	r.React(typeKey[E]{}, typedHandler(h))
+}
+
+func typedHandler[E any](h func(E)) Subscriber[Data] {
+	return func(d Data) {
+		// a nil value of an interface type E doesn't satisfy the assertion, so its zero value is passed
+		e, _ := d.(E)
+		h(e)
+	}
+}
+
+// Emit fires an event of type E, invoking the handler registered with RegisterHandler for type E, if any.
+func Emit[E any](r *Reactor[Data], e E) {
+	r.Fire(typeKey[E]{}, e)
+}
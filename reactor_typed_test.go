@@ -0,0 +1,83 @@
+package eventual2go
+
+import (
+	"testing"
+	"time"
+)
+
+type testCreated struct {
+	id int
+}
+
+type testRenamed struct {
+	id   int
+	name string
+}
+
+func TestReactorTypedEvents(t *testing.T) {
+	r := NewReactor[Data]()
+	defer r.Shutdown(nil)
+	c := make(chan string, 3)
+	RegisterHandler(r, func(e testCreated) {
+		c <- "created"
+	})
+	RegisterHandler(r, func(e testRenamed) {
+		c <- e.name
+	})
+	RegisterHandler(r, func(e int) {
+		c <- "int"
+	})
+
+	Emit(r, testCreated{1})
+	Emit(r, testRenamed{1, "test"})
+	Emit(r, 1)
+	Emit(r, "unhandled")
+
+	for _, want := range []string{"created", "test", "int"} {
+		select {
+		case got := <-c:
+			if got != want {
+				t.Error("got wrong event", got, "want", want)
+			}
+		case <-time.After(10 * time.Millisecond):
+			t.Fatal("no response")
+		}
+	}
+}
+
+func TestReactorTypedEventsDontCollide(t *testing.T) {
+	r := NewReactor[Data]()
+	defer r.Shutdown(nil)
+	c := make(chan int, 2)
+	RegisterHandler(r, func(d int) {
+		c <- d
+	})
+	r.React(0, func(Data) {
+		c <- -1
+	})
+
+	Emit(r, 0)
+	r.Fire(0, 0)
+	if <-c != 0 || <-c != -1 {
+		t.Error("typed and classified events collided")
+	}
+}
+
+func TestReactorTypedEventsNilInterface(t *testing.T) {
+	r := NewReactor[Data]()
+	defer r.Shutdown(nil)
+	c := make(chan error, 1)
+	RegisterHandler(r, func(e error) {
+		c <- e
+	})
+
+	Emit[error](r, nil)
+	select {
+	case err := <-c:
+		if err != nil {
+			t.Error("got wrong event", err)
+		}
+	case <-time.After(10 * time.Millisecond):
+		t.Fatal("no response")
+	}
+}
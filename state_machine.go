@@ -0,0 +1,333 @@
+package eventual2go
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+)
+
+// ErrSubStateCycle is returned when adding a sub-state, which is already an ancestor of the parent state.
+var ErrSubStateCycle = errors.New("Sub-state cycle")
+
+// A StateMachine is a hierarchical finite state machine, driven by the events of a Reactor. Transitions are keyed by
+// event classifier and may be guarded by a Filter on the event data. States can be nested, transitions of a state apply
+// to all of its sub-states. Entry and exit actions are invoked under the reactor's lock like any other handler.
+type StateMachine[S comparable, T any] struct {
+	m           *sync.Mutex
+	r           *Reactor[T]
+	initial     S
+	current     *stateConfig[S, T]
+	started     bool
+	states      map[S]*stateConfig[S, T]
+	order       []*stateConfig[S, T]
+	transitions []*transition[S, T]
+	classifiers map[interface{}]bool
+	change      *Observable[S]
+}
+
+// Transition describes a transition of a StateMachine.
+type Transition[S comparable] struct {
+	From       S
+	To         S
+	Classifier interface{}
+	Guarded    bool
+}
+
+type transition[S comparable, T any] struct {
+	Transition[S]
+	guard Filter[T]
+}
+
+type stateConfig[S comparable, T any] struct {
+	id      S
+	parent  *stateConfig[S, T]
+	initial *stateConfig[S, T]
+	entry   []Subscriber[T]
+	exit    []Subscriber[T]
+}
+
+// NewStateMachine creates a new StateMachine driven by the given Reactor, which enters the initial state on Start.
+func NewStateMachine[S comparable, T any](r *Reactor[T], initial S) (sm *StateMachine[S, T]) {
+	sm = &StateMachine[S, T]{
+		m:           &sync.Mutex{},
+		r:           r,
+		initial:     initial,
+		states:      map[S]*stateConfig[S, T]{},
+		classifiers: map[interface{}]bool{},
+		change:      NewObservable(initial),
+	}
+	sm.current = sm.state(initial)
+	r.AddHandler(sm, sm.start)
+	return
+}
+
+// must be called with lock held.
+func (sm *StateMachine[S, T]) state(s S) *stateConfig[S, T] {
+	sc, ok := sm.states[s]
+	if !ok {
+		sc = &stateConfig[S, T]{id: s}
+		sm.states[s] = sc
+		sm.order = append(sm.order, sc)
+	}
+	return sc
+}
+
+// AddState declares a state. States are declared implicitly when used, declaring them explicitly only defines their
+// order in the DOT export.
+func (sm *StateMachine[S, T]) AddState(s S) {
+	sm.m.Lock()
+	defer sm.m.Unlock()
+	sm.state(s)
+}
+
+// AddSubState declares a state nested in the parent state. The first sub-state of a parent is its initial sub-state,
+// which gets entered when the parent is the target of a transition. Returns ErrSubStateCycle, if the state is the parent
+// or one of its ancestors.
+func (sm *StateMachine[S, T]) AddSubState(parent, s S) (err error) {
+	sm.m.Lock()
+	defer sm.m.Unlock()
+	p := sm.state(parent)
+	sc := sm.state(s)
+	for a := p; a != nil; a = a.parent {
+		if a == sc {
+			return ErrSubStateCycle
+		}
+	}
+	sc.parent = p
+	if p.initial == nil {
+		p.initial = sc
+	}
+	return
+}
+
+// OnEntry registers an action, which gets invoked with the event data when the state is entered.
+func (sm *StateMachine[S, T]) OnEntry(s S, action Subscriber[T]) {
+	sm.m.Lock()
+	defer sm.m.Unlock()
+	sc := sm.state(s)
+	sc.entry = append(sc.entry, action)
+}
+
+// OnExit registers an action, which gets invoked with the event data when the state is left.
+func (sm *StateMachine[S, T]) OnExit(s S, action Subscriber[T]) {
+	sm.m.Lock()
+	defer sm.m.Unlock()
+	sc := sm.state(s)
+	sc.exit = append(sc.exit, action)
+}
+
+// AddTransition adds a transition, which is taken when an event with the given classifier is handled while the machine
+// is in the from state or one of its sub-states. If a guard is given, the transition is only taken if the guard returns
+// TRUE for the event data. Transitions are checked in the order they were added, starting with the innermost state.
+func (sm *StateMachine[S, T]) AddTransition(from S, classifier interface{}, to S, guard Filter[T]) {
+	sm.m.Lock()
+	sm.state(from)
+	sm.state(to)
+	sm.transitions = append(sm.transitions, &transition[S, T]{
+		Transition: Transition[S]{
+			From:       from,
+			To:         to,
+			Classifier: classifier,
+			Guarded:    guard != nil,
+		},
+		guard: guard,
+	})
+	register := !sm.classifiers[classifier]
+	sm.classifiers[classifier] = true
+	sm.m.Unlock()
+	if register {
+		sm.r.AddHandler(classifier, sm.handle(classifier))
+	}
+}
+
+// Start enters the initial state, invoking its entry actions. Events handled before are ignored.
+func (sm *StateMachine[S, T]) Start() {
+	var d T
+	sm.r.Fire(sm, d)
+}
+
+// State returns the current state. While the exit and entry actions of a transition run, it is still the source state.
+func (sm *StateMachine[S, T]) State() S {
+	sm.m.Lock()
+	defer sm.m.Unlock()
+	return sm.current.id
+}
+
+// Observable returns an Observable of the current state.
+func (sm *StateMachine[S, T]) Observable() *Observable[S] {
+	return sm.change
+}
+
+// Transitions returns all transitions in the order they were added.
+func (sm *StateMachine[S, T]) Transitions() (ts []Transition[S]) {
+	sm.m.Lock()
+	defer sm.m.Unlock()
+	for _, t := range sm.transitions {
+		ts = append(ts, t.Transition)
+	}
+	return
+}
+
+func (sm *StateMachine[S, T]) start(d T) {
+	sm.m.Lock()
+	if sm.started {
+		sm.m.Unlock()
+		return
+	}
+	sm.started = true
+	enter := append(sm.path(sm.current), sm.initials(sm.current)...)
+	sm.m.Unlock()
+	sm.enter(enter, d)
+}
+
+func (sm *StateMachine[S, T]) handle(classifier interface{}) Subscriber[T] {
+	return func(d T) {
+		sm.m.Lock()
+		if !sm.started {
+			sm.m.Unlock()
+			return
+		}
+		candidates := sm.candidates(classifier)
+		sm.m.Unlock()
+
+		t := sm.find(candidates, d)
+		if t == nil {
+			return
+		}
+
+		sm.m.Lock()
+		exit, enter := sm.plan(sm.states[t.From], sm.states[t.To])
+		sm.m.Unlock()
+
+		for _, sc := range exit {
+			for _, a := range sc.exit {
+				a(d)
+			}
+		}
+		sm.enter(enter, d)
+	}
+}
+
+// candidates returns the transitions for the classifier of the current state and its ancestors, innermost first. Must
+// be called with lock held.
+func (sm *StateMachine[S, T]) candidates(classifier interface{}) (ts []*transition[S, T]) {
+	for sc := sm.current; sc != nil; sc = sc.parent {
+		for _, t := range sm.transitions {
+			if t.From == sc.id && t.Classifier == classifier {
+				ts = append(ts, t)
+			}
+		}
+	}
+	return
+}
+
+// find returns the first transition, which guard passes. The guards are run without the lock, so they may access the
+// state machine.
+func (sm *StateMachine[S, T]) find(ts []*transition[S, T], d T) *transition[S, T] {
+	for _, t := range ts {
+		if t.guard == nil || t.guard(d) {
+			return t
+		}
+	}
+	return nil
+}
+
+// plan returns the states to exit, innermost first, and the states to enter, outermost first, for a transition. The
+// source and target are exited and entered again, if one contains the other. Must be called with lock held.
+func (sm *StateMachine[S, T]) plan(from, to *stateConfig[S, T]) (exit, enter []*stateConfig[S, T]) {
+	fromPath, toPath := sm.path(from), sm.path(to)
+	common := 0
+	for common < len(fromPath) && common < len(toPath) && fromPath[common] == toPath[common] {
+		common++
+	}
+	if common == len(fromPath) || common == len(toPath) {
+		common--
+	}
+	for sc := sm.current; sc != nil && sm.depth(sc) >= common; sc = sc.parent {
+		exit = append(exit, sc)
+	}
+	enter = append(toPath[common:], sm.initials(to)...)
+	return
+}
+
+// enter invokes the entry actions of the states and afterwards makes the last one the current state.
+func (sm *StateMachine[S, T]) enter(states []*stateConfig[S, T], d T) {
+	if len(states) == 0 {
+		return
+	}
+	for _, sc := range states {
+		for _, a := range sc.entry {
+			a(d)
+		}
+	}
+	current := states[len(states)-1]
+	sm.m.Lock()
+	sm.current = current
+	sm.m.Unlock()
+	sm.change.Change(current.id)
+}
+
+// path returns the state and its ancestors, outermost first. Must be called with lock held.
+func (sm *StateMachine[S, T]) path(sc *stateConfig[S, T]) (p []*stateConfig[S, T]) {
+	for ; sc != nil; sc = sc.parent {
+		p = append([]*stateConfig[S, T]{sc}, p...)
+	}
+	return
+}
+
+// must be called with lock held.
+func (sm *StateMachine[S, T]) depth(sc *stateConfig[S, T]) int {
+	return len(sm.path(sc)) - 1
+}
+
+// initials returns the chain of initial sub-states of the state. Must be called with lock held.
+func (sm *StateMachine[S, T]) initials(sc *stateConfig[S, T]) (is []*stateConfig[S, T]) {
+	for sc = sc.initial; sc != nil; sc = sc.initial {
+		is = append(is, sc)
+	}
+	return
+}
+
+// DOT returns the states and transitions in the DOT language of Graphviz. Nested states are rendered as clusters.
+func (sm *StateMachine[S, T]) DOT() string {
+	sm.m.Lock()
+	defer sm.m.Unlock()
+	b := &strings.Builder{}
+	b.WriteString("digraph {\n")
+	for _, sc := range sm.order {
+		if sc.parent == nil {
+			sm.writeState(b, sc, "\t")
+		}
+	}
+	for _, t := range sm.transitions {
+		label := fmt.Sprint(t.Classifier)
+		if t.Guarded {
+			label += " [guarded]"
+		}
+		fmt.Fprintf(b, "\t%q -> %q [label=%q];\n", fmt.Sprint(t.From), fmt.Sprint(t.To), label)
+	}
+	b.WriteString("}\n")
+	return b.String()
+}
+
+// must be called with lock held.
+func (sm *StateMachine[S, T]) writeState(b *strings.Builder, sc *stateConfig[S, T], indent string) {
+	var children []*stateConfig[S, T]
+	for _, c := range sm.order {
+		if c.parent == sc {
+			children = append(children, c)
+		}
+	}
+	name := fmt.Sprint(sc.id)
+	if len(children) == 0 {
+		fmt.Fprintf(b, "%s%q;\n", indent, name)
+		return
+	}
+	fmt.Fprintf(b, "%ssubgraph %q {\n", indent, "cluster_"+name)
+	fmt.Fprintf(b, "%s\tlabel=%q;\n", indent, name)
+	for _, c := range children {
+		sm.writeState(b, c, indent+"\t")
+	}
+	fmt.Fprintf(b, "%s}\n", indent)
+}
@@ -0,0 +1,163 @@
+package eventual2go
+
+import (
+	"strings"
+	"testing"
+)
+
+func logAction(c chan string, action string) Subscriber[int] {
+	return func(int) {
+		c <- action
+	}
+}
+
+func newTestStateMachine() (*Reactor[int], *StateMachine[string, int], chan string) {
+	r := NewReactor[int]()
+	sm := NewStateMachine[string, int](r, "idle")
+	c := make(chan string, 20)
+	for _, s := range []string{"idle", "running", "fast", "slow"} {
+		sm.OnEntry(s, logAction(c, "enter "+s))
+		sm.OnExit(s, logAction(c, "exit "+s))
+	}
+	sm.AddSubState("running", "fast")
+	sm.AddSubState("running", "slow")
+	sm.AddTransition("idle", "start", "running", nil)
+	sm.AddTransition("fast", "toggle", "slow", nil)
+	sm.AddTransition("slow", "toggle", "fast", nil)
+	sm.AddTransition("running", "stop", "idle", nil)
+	sm.AddTransition("running", "restart", "running", nil)
+	return r, sm, c
+}
+
+func TestStateMachine(t *testing.T) {
+	r, sm, c := newTestStateMachine()
+	defer r.Shutdown(nil)
+
+	r.Fire("start", 0)
+	sm.Start()
+	receiveStrings(t, c, "enter idle")
+
+	r.Fire("start", 0)
+	receiveStrings(t, c, "exit idle", "enter running", "enter fast")
+	if sm.State() != "fast" {
+		t.Error("wrong state", sm.State())
+	}
+
+	r.Fire("toggle", 0)
+	receiveStrings(t, c, "exit fast", "enter slow")
+
+	r.Fire("restart", 0)
+	receiveStrings(t, c, "exit slow", "exit running", "enter running", "enter fast")
+
+	r.Fire("stop", 0)
+	receiveStrings(t, c, "exit fast", "exit running", "enter idle")
+	if sm.State() != "idle" {
+		t.Error("wrong state", sm.State())
+	}
+
+	r.Fire("toggle", 0)
+	receiveStrings(t, c)
+}
+
+func TestStateMachineGuard(t *testing.T) {
+	r := NewReactor[int]()
+	defer r.Shutdown(nil)
+	sm := NewStateMachine[string, int](r, "locked")
+	sm.AddTransition("locked", "code", "open", func(d int) bool { return d == 1234 })
+	sm.AddTransition("locked", "code", "alarm", nil)
+	c, _ := sm.Observable().Stream().AsChan()
+	sm.Start()
+
+	r.Fire("code", 1234)
+	receiveStrings(t, c, "locked", "open")
+	if sm.State() != "open" {
+		t.Error("wrong state", sm.State())
+	}
+}
+
+func TestStateMachineGuardFallthrough(t *testing.T) {
+	r := NewReactor[int]()
+	defer r.Shutdown(nil)
+	sm := NewStateMachine[string, int](r, "locked")
+	sm.AddTransition("locked", "code", "open", func(d int) bool { return d == 1234 })
+	sm.AddTransition("locked", "code", "alarm", nil)
+	c, _ := sm.Observable().Stream().AsChan()
+	sm.Start()
+
+	r.Fire("code", 1)
+	receiveStrings(t, c, "locked", "alarm")
+}
+
+func TestStateMachineExport(t *testing.T) {
+	r, sm, _ := newTestStateMachine()
+	defer r.Shutdown(nil)
+	sm.AddTransition("idle", "guarded", "slow", func(int) bool { return true })
+
+	ts := sm.Transitions()
+	if len(ts) != 6 || ts[0] != (Transition[string]{"idle", "running", "start", false}) || !ts[5].Guarded {
+		t.Error("wrong transitions", ts)
+	}
+
+	dot := sm.DOT()
+	for _, want := range []string{
+		"digraph {",
+		"\t\"idle\";\n",
+		"\tsubgraph \"cluster_running\" {\n\t\tlabel=\"running\";\n\t\t\"fast\";\n\t\t\"slow\";\n\t}\n",
+		"\t\"idle\" -> \"running\" [label=\"start\"];\n",
+		"\t\"idle\" -> \"slow\" [label=\"guarded [guarded]\"];\n",
+	} {
+		if !strings.Contains(dot, want) {
+			t.Errorf("DOT doesn't contain %q:\n%s", want, dot)
+		}
+	}
+}
+
+func TestStateMachineGuardAccess(t *testing.T) {
+	r := NewReactor[int]()
+	defer r.Shutdown(nil)
+	sm := NewStateMachine[string, int](r, "locked")
+	sm.AddTransition("locked", "code", "open", func(int) bool {
+		return sm.State() == "locked" && len(sm.Transitions()) == 1
+	})
+	c, _ := sm.Observable().Stream().AsChan()
+	sm.Start()
+
+	r.Fire("code", 1234)
+	receiveStrings(t, c, "locked", "open")
+}
+
+func TestStateMachineStateDuringActions(t *testing.T) {
+	r, sm, _ := newTestStateMachine()
+	defer r.Shutdown(nil)
+	c := make(chan string, 10)
+	sm.OnExit("fast", func(int) { c <- "exit " + sm.State() })
+	sm.OnEntry("slow", func(int) { c <- "enter " + sm.State() })
+	sm.Start()
+
+	r.Fire("start", 0)
+	r.Fire("toggle", 0)
+	receiveStrings(t, c, "exit fast", "enter fast")
+}
+
+func TestStateMachineSubStateCycle(t *testing.T) {
+	r := NewReactor[int]()
+	defer r.Shutdown(nil)
+	sm := NewStateMachine[string, int](r, "a")
+	if err := sm.AddSubState("a", "b"); err != nil {
+		t.Fatal(err)
+	}
+	if err := sm.AddSubState("b", "a"); err != ErrSubStateCycle {
+		t.Error("wrong error", err)
+	}
+	if err := sm.AddSubState("a", "a"); err != ErrSubStateCycle {
+		t.Error("wrong error", err)
+	}
+	c := make(chan string, 10)
+	sm.OnEntry("a", logAction(c, "enter a"))
+	sm.OnEntry("b", logAction(c, "enter b"))
+	sm.AddTransition("b", "go", "a", nil)
+	sm.Start()
+
+	r.Fire("go", 0)
+	receiveStrings(t, c, "enter a", "enter b", "enter a", "enter b")
+}
@@ -1,88 +1,185 @@
 package eventual2go
 
 import (
+	"context"
 	"sync"
 )
 
 // A Stream can be consumed or new streams be derived by registering handler functions.
 type Stream[T any] struct {
-	m     *sync.Mutex
-	next  *Future[*streamEvent[T]]
-	close *Completer[Data]
+	m         *sync.Mutex
+	next      *Future[*streamEvent[T]]
+	close     *Completer[Data]
+	replay    *replayBuffer[T]
+	listeners map[*drainingListener[T]]struct{}
+	closed    bool
+	closeErr  error
+	panics    PanicHandler
+	executor  Executor
+	flush     func() // invoked on close before the listeners are closed, so a controller can add queued elements
 }
 
 // NewStream returns a new stream. Data can not be added to a Stream manually, use a StreamController instead.
-func newStream[T any](next *Future[*streamEvent[T]]) (s *Stream[T]) {
+func newStream[T any](next *Future[*streamEvent[T]], e Executor) (s *Stream[T]) {
 	s = &Stream[T]{
-		m:     &sync.Mutex{},
-		next:  next,
-		close: NewCompleter[Data](),
+		m:         &sync.Mutex{},
+		next:      next,
+		close:     NewCompleter[Data](),
+		listeners: map[*drainingListener[T]]struct{}{},
+		executor:  e,
 	}
+	s.Closed().Then(s.onClosed)
+	s.Closed().Err(s.onClosedError)
 	return
 }
 
 func (s *Stream[T]) updateNext(next *Future[*streamEvent[T]]) {
 	s.m.Lock()
 	defer s.m.Unlock()
+	if s.replay != nil {
+		s.replay.retain(s.next)
+	}
 	s.next = next
 }
 
-// Close closes the Stream and its assigned StreamController.
+// head returns the event new subscribers start with, which is the next event or the oldest replayed one. Must be
+// called with lock held.
+func (s *Stream[T]) head() *Future[*streamEvent[T]] {
+	if s.replay != nil {
+		return s.replay.head(s.next)
+	}
+	return s.next
+}
+
+// subscribe registers a handler for the event new subscribers start with. Replayed events are already completed, so
+// the handler gets executed asynchronously like for any other completed event.
+func (s *Stream[T]) subscribe(h CompletionHandler[*streamEvent[T]]) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	head := s.head()
+	if head.Completed() {
+		s.execute(func() { head.Then(h) })
+		return
+	}
+	head.Then(h)
+}
+
+// execute runs the function with the Executor of the stream or in a new go-routine, if there is none.
+func (s *Stream[T]) execute(f func()) {
+	if s.executor == nil {
+		go f()
+		return
+	}
+	s.executor.Execute(f)
+}
+
+// SetPanicHandler sets a PanicHandler for the subscribers and error handlers of the Stream, which overrides the one set
+// with the package-level SetPanicHandler. A panicking subscriber doesn't terminate its subscription.
+func (s *Stream[T]) SetPanicHandler(h PanicHandler) {
+	s.m.Lock()
+	defer s.m.Unlock()
+	s.panics = h
+}
+
+func (s *Stream[T]) panicHandler() PanicHandler {
+	s.m.Lock()
+	defer s.m.Unlock()
+	return s.panics
+}
+
+// Close closes the Stream and its assigned StreamController. Closing an already closed Stream has no effect.
 func (s *Stream[T]) Close() {
-	s.close.Complete(true)
+	s.close.TryComplete(true)
 }
 
-// Closed returns a Future which completes upon closing of the Stream.
+// CloseWithError closes the Stream and its assigned StreamController because of a failure. Closing an already closed
+// Stream has no effect.
+func (s *Stream[T]) CloseWithError(err error) {
+	s.close.TryCompleteError(err)
+}
+
+// Closed returns a Future which completes upon closing of the Stream. If the Stream got closed with CloseWithError, the
+// Future fails with the given error.
 func (s *Stream[T]) Closed() (f *Future[Data]) {
 	return s.close.Future()
 }
 
+func (s *Stream[T]) onClose(f func()) {
+	s.Closed().Then(func(Data) { f() })
+	s.Closed().Err(func(error) { f() })
+}
+
 // CloseOnFuture closes the Stream upon completion of Future.
 func (s *Stream[T]) CloseOnFuture(f *Future[Data]) {
-	s.close.CompleteOnFuture(f)
+	f.Then(tryCompleteFuture(s.close))
+	f.Err(tryCompleteFutureError(s.close))
 }
 
-// Listen registers a subscriber. Returns a Completer, which can be used to terminate the subcription.
-func (s *Stream[T]) Listen(sr Subscriber[T]) (stop *Completer[Data]) {
-	stop = NewCompleter[Data]()
-	s.m.Lock()
-	defer s.m.Unlock()
-	s.next.Then(listen(sr, stop.Future(), true))
-	return
+// Listen registers a subscriber. Returns a Subscription, which can be used to terminate the subscription. Error events
+// are not passed to the subscriber, use OnError or ListenWithError to receive them.
+func (s *Stream[T]) Listen(sr Subscriber[T]) (sub *Subscription) {
+	return s.ListenWithError(sr, nil)
 }
 
-// ListenNonBlocking is the same as Listen, but the subscriber is not blocking the subcription.
-func (s *Stream[T]) ListenNonBlocking(sr Subscriber[T]) (stop *Completer[Data]) {
-	stop = NewCompleter[Data]()
-	s.m.Lock()
-	defer s.m.Unlock()
-	s.next.Then(listen(sr, stop.Future(), false))
+// ListenWithError registers a subscriber and an error handler, which gets invoked for every error event. Returns a
+// Subscription, which can be used to terminate the subscription.
+func (s *Stream[T]) ListenWithError(sr Subscriber[T], eh ErrorHandler) (sub *Subscription) {
+	return s.listen(sr, eh, true)
+}
+
+// ListenNonBlocking is the same as Listen, but the subscriber is not blocking the subcription. The subscriber is invoked
+// with the Executor of the stream, if any.
+func (s *Stream[T]) ListenNonBlocking(sr Subscriber[T]) (sub *Subscription) {
+	return s.listen(sr, nil, false)
+}
+
+// OnError registers an error handler, which gets invoked for every error event. Returns a Subscription, which can be
+// used to terminate the subscription.
+func (s *Stream[T]) OnError(eh ErrorHandler) (sub *Subscription) {
+	return s.ListenWithError(nil, eh)
+}
+
+// ListenContext is the same as Listen, but the subscription is terminated when the context is done.
+func (s *Stream[T]) ListenContext(ctx context.Context, sr Subscriber[T]) (sub *Subscription) {
+	sub = s.Listen(sr)
+	go sub.cancelOnContext(ctx)
 	return
 }
 
-func listen[T any](sr Subscriber[T], stop *Future[Data], block bool) CompletionHandler[*streamEvent[T]] {
-	return func(evt *streamEvent[T]) {
-		if !stop.Completed() {
-			if block {
-				sr(evt.data)
-			} else {
-				go sr(evt.data)
-			}
-			evt.next.Then(listen(sr, stop, block))
-		}
-	}
+func (s *Stream[T]) listen(sr Subscriber[T], eh ErrorHandler, block bool) (sub *Subscription) {
+	sub = newSubscription()
+	s.addListener(&drainingListener[T]{
+		sr:     deliverTo(sub, sr),
+		eh:     deliverErrorTo(sub, eh),
+		stop:   sub.Done(),
+		closed: sub.end,
+		block:  block,
+	})
+	return
 }
 
-// Derive creates a derived stream from a DeriveSubscriber. Mainly used internally.
+// Derive creates a derived stream from a DeriveSubscriber. Error events are forwarded to the derived stream and the
+// derived stream gets closed together with the source, after all elements added before closing were handled. The derived
+// stream of a replaying stream replays its elements with the same settings, so late subscribers don't miss the elements
+// derived from the replayed ones. Mainly used internally.
 func DeriveStream[T, V any](s *Stream[T], dsr DeriveSubscriber[T, V]) (ds *Stream[V]) {
-	sc := NewStreamController[V]()
+	sc := NewStreamControllerWithExecutor[V](s.executor)
 	ds = sc.Stream()
-	s.m.Lock()
-	defer s.m.Unlock()
-	s.next.Then(listen(derive(sc, dsr), ds.close.Future(), true))
+	ds.replay = deriveReplay[T, V](s.replay)
+	s.listenUntil(derive(sc, dsr), sc.AddError, ds.Closed(), closeStream(ds))
 	return
 }
 
+func closeStream[T any](s *Stream[T]) func(error) {
+	return func(err error) {
+		if err != nil {
+			s.CloseWithError(err)
+		} else {
+			s.Close()
+		}
+	}
+}
+
 func derive[T, V any](sc *StreamController[V], dsr DeriveSubscriber[T, V]) Subscriber[T] {
 	return func(d T) {
 		dsr(sc, d)
@@ -157,44 +254,57 @@ func filterNot[T any](f []Filter[T]) DeriveSubscriber[T, T] {
 	}
 }
 
-// First returns a future that will be completed with the first element added to the stream.
+// First returns a future that will be completed with the first element added to the stream. If an error event is added
+// first, the future fails with its error.
 func (s *Stream[T]) First() (f *Future[T]) {
 	c := NewCompleter[T]()
 	f = c.Future()
-	s.m.Lock()
-	defer s.m.Unlock()
-	s.next.Then(first(c))
+	s.subscribe(first(c))
 	return
 }
 
 func first[T any](c *Completer[T]) CompletionHandler[*streamEvent[T]] {
 	return func(evt *streamEvent[T]) {
+		defer evt.done()
+		if evt.err != nil {
+			c.CompleteError(evt.err)
+			return
+		}
 		c.Complete(evt.data)
 	}
 }
 
 // FirstWhere returns a future that will be completed with the first element added to the stream where filter returns TRUE.
+// If an error event is added first, the future fails with its error.
 func (s *Stream[T]) FirstWhere(f ...Filter[T]) (fw *Future[T]) {
 	c := NewCompleter[T]()
 	fw = c.Future()
 	w := s.Where(f...)
-	w.Listen(closeOnFirst(c, w))
+	w.ListenWithError(closeOnFirst(c, w), closeOnFirstError(c, w))
 	return
 }
 
 func closeOnFirst[T any](c *Completer[T], s *Stream[T]) Subscriber[T] {
 	return func(d T) {
-		c.Complete(d)
+		c.TryComplete(d)
+		s.Close()
+	}
+}
+
+func closeOnFirstError[T any](c *Completer[T], s *Stream[T]) ErrorHandler {
+	return func(err error) {
+		c.TryCompleteError(err)
 		s.Close()
 	}
 }
 
 // FirstWhereNot returns a future that will be completed with the first element added to the stream where filter returns FALSE.
+// If an error event is added first, the future fails with its error.
 func (s *Stream[T]) FirstWhereNot(f ...Filter[T]) (fw *Future[T]) {
 	c := NewCompleter[T]()
 	fw = c.Future()
 	w := s.WhereNot(f...)
-	w.Listen(closeOnFirst(c, w))
+	w.ListenWithError(closeOnFirst(c, w), closeOnFirstError(c, w))
 	return
 }
 
@@ -204,11 +314,12 @@ func (s *Stream[T]) Split(f Filter[T]) (ts *Stream[T], fs *Stream[T]) {
 }
 
 // AsChan returns a channel where all items will be pushed. Note items while be queued in a fifo since the stream must
-// not block.
-func (s *Stream[T]) AsChan() (c chan T, stop *Completer[Data]) {
+// not block, use a BoundedStreamController to limit the queue. Error events are not pushed to the channel. The channel
+// gets closed when the subscription terminates.
+func (s *Stream[T]) AsChan() (c chan T, sub *Subscription) {
 	c = make(chan T)
-	stop = s.Listen(pipeToChan(c))
-	stop.Future().Then(closeChan(c))
+	sub = s.Listen(pipeToChan(c))
+	sub.Done().Then(closeChan(c))
 	return
 }
 
@@ -0,0 +1,179 @@
+package eventual2go
+
+import "sync"
+
+// AsyncErrorPolicy defines how MapAsyncWithErrorPolicy handles failed futures.
+type AsyncErrorPolicy int
+
+const (
+	// AsyncForwardErrors adds the error of a failed future as error event to the mapped stream.
+	AsyncForwardErrors AsyncErrorPolicy = iota
+	// AsyncDropErrors discards failed futures.
+	AsyncDropErrors
+)
+
+// MapAsync returns a stream, which receives the results of the futures returned by the FutureTransformer for every
+// element of the source stream. Up to concurrency futures are in flight at once, further elements are held back until a
+// future completes. If ordered is TRUE, the results are added in the order of the source elements, otherwise in the
+// order of completion. Failed futures are added as error events. The mapped stream gets closed after the source is
+// closed and all futures completed.
+func MapAsync[T, V any](s *Stream[T], t FutureTransformer[T, V], concurrency int, ordered bool) (ms *Stream[V]) {
+	return MapAsyncWithErrorPolicy(s, t, concurrency, ordered, AsyncForwardErrors)
+}
+
+// MapAsyncWithErrorPolicy is the same as MapAsync, but failed futures are handled according to the AsyncErrorPolicy.
+// Error events of the source stream are always forwarded.
+func MapAsyncWithErrorPolicy[T, V any](s *Stream[T], t FutureTransformer[T, V], concurrency int, ordered bool, policy AsyncErrorPolicy) (ms *Stream[V]) {
+	if concurrency < 1 {
+		concurrency = 1
+	}
+	sc := NewStreamControllerWithExecutor[V](s.executor)
+	ms = sc.Stream()
+	m := &sync.Mutex{}
+	am := &asyncMapper[T, V]{
+		m:        m,
+		slotFree: sync.NewCond(m),
+		sc:       sc,
+		t:        t,
+		limit:    concurrency,
+		ordered:  ordered,
+		policy:   policy,
+	}
+	ms.onClose(am.stop)
+	s.listenUntil(am.add, am.addError, ms.Closed(), am.close)
+	return
+}
+
+type asyncMapper[T, V any] struct {
+	m        *sync.Mutex
+	slotFree *sync.Cond
+	sc       *StreamController[V]
+	t        FutureTransformer[T, V]
+	limit    int
+	ordered  bool
+	policy   AsyncErrorPolicy
+	inFlight int
+	pending  []*asyncResult[V] // results waiting for their predecessors, only used if ordered
+	closed   bool
+	closeErr error
+	stopped  bool
+}
+
+type asyncResult[V any] struct {
+	done   bool
+	data   V
+	err    error
+	source bool // error event of the source stream
+}
+
+func (am *asyncMapper[T, V]) add(d T) {
+	am.m.Lock()
+	for !am.stopped && am.inFlight >= am.limit {
+		am.slotFree.Wait()
+	}
+	if am.stopped {
+		am.m.Unlock()
+		return
+	}
+	am.inFlight++
+	r := &asyncResult[V]{}
+	if am.ordered {
+		am.pending = append(am.pending, r)
+	}
+	am.m.Unlock()
+
+	f, err := am.transform(d)
+	if err != nil {
+		var v V
+		am.finish(r, v, err)
+		return
+	}
+	f.Then(am.complete(r))
+	f.Err(am.fail(r))
+}
+
+// transform invokes the FutureTransformer, converting a panic into a PanicError, so the slot gets released.
+func (am *asyncMapper[T, V]) transform(d T) (f *Future[V], err error) {
+	defer func() {
+		if v := recover(); v != nil {
+			err = newPanicError(v)
+		}
+	}()
+	f = am.t(d)
+	return
+}
+
+func (am *asyncMapper[T, V]) complete(r *asyncResult[V]) CompletionHandler[V] {
+	return func(d V) {
+		am.finish(r, d, nil)
+	}
+}
+
+func (am *asyncMapper[T, V]) fail(r *asyncResult[V]) ErrorHandler {
+	return func(err error) {
+		var d V
+		am.finish(r, d, err)
+	}
+}
+
+func (am *asyncMapper[T, V]) finish(r *asyncResult[V], d V, err error) {
+	am.m.Lock()
+	defer am.m.Unlock()
+	am.inFlight--
+	am.slotFree.Signal()
+	r.done, r.data, r.err = true, d, err
+	if !am.ordered {
+		am.emit(r)
+	}
+	am.flush()
+}
+
+func (am *asyncMapper[T, V]) addError(err error) {
+	am.m.Lock()
+	defer am.m.Unlock()
+	r := &asyncResult[V]{done: true, err: err, source: true}
+	if !am.ordered {
+		am.emit(r)
+		return
+	}
+	am.pending = append(am.pending, r)
+	am.flush()
+}
+
+func (am *asyncMapper[T, V]) close(err error) {
+	am.m.Lock()
+	defer am.m.Unlock()
+	am.closed = true
+	am.closeErr = err
+	am.flush()
+}
+
+func (am *asyncMapper[T, V]) stop() {
+	am.m.Lock()
+	defer am.m.Unlock()
+	am.stopped = true
+	am.slotFree.Broadcast()
+}
+
+// flush adds all completed results in order and closes the mapped stream, if the source is closed and no future is
+// left. Must be called with lock held.
+func (am *asyncMapper[T, V]) flush() {
+	for len(am.pending) != 0 && am.pending[0].done {
+		r := am.pending[0]
+		am.pending[0] = nil
+		am.pending = am.pending[1:]
+		am.emit(r)
+	}
+	if am.closed && am.inFlight == 0 && len(am.pending) == 0 {
+		closeStream(am.sc.Stream())(am.closeErr)
+	}
+}
+
+// must be called with lock held.
+func (am *asyncMapper[T, V]) emit(r *asyncResult[V]) {
+	if r.err == nil {
+		am.sc.Add(r.data)
+	} else if r.source || am.policy == AsyncForwardErrors {
+		am.sc.AddError(r.err)
+	}
+}
@@ -0,0 +1,169 @@
+package eventual2go
+
+import (
+	"sync"
+	"testing"
+	"time"
+)
+
+// pendingFutures hands out a Completer for every element, so a test can complete them in any order.
+type pendingFutures struct {
+	m  sync.Mutex
+	cs map[int]*Completer[int]
+	c  chan int
+}
+
+func newPendingFutures() *pendingFutures {
+	return &pendingFutures{
+		cs: map[int]*Completer[int]{},
+		c:  make(chan int, 10),
+	}
+}
+
+func (pf *pendingFutures) start(d int) *Future[int] {
+	pf.m.Lock()
+	defer pf.m.Unlock()
+	c := NewCompleter[int]()
+	pf.cs[d] = c
+	pf.c <- d
+	return c.Future()
+}
+
+func (pf *pendingFutures) get(d int) *Completer[int] {
+	pf.m.Lock()
+	defer pf.m.Unlock()
+	return pf.cs[d]
+}
+
+func (pf *pendingFutures) waitStarted(t *testing.T, want ...int) {
+	t.Helper()
+	for _, w := range want {
+		select {
+		case d := <-pf.c:
+			if d != w {
+				t.Fatal("started wrong element", d)
+			}
+		case <-time.After(10 * time.Millisecond):
+			t.Fatal("future wasn't started")
+		}
+	}
+	select {
+	case d := <-pf.c:
+		t.Fatal("too many futures in flight", d)
+	case <-time.After(1 * time.Millisecond):
+	}
+}
+
+func TestMapAsyncOrdered(t *testing.T) {
+	pf := newPendingFutures()
+	sc := NewStreamController[int]()
+	ms := MapAsync(sc.Stream(), pf.start, 2, true)
+	c, _ := ms.AsChan()
+	for i := 1; i <= 3; i++ {
+		sc.Add(i)
+	}
+
+	pf.waitStarted(t, 1, 2)
+	pf.get(2).Complete(20)
+	pf.waitStarted(t, 3)
+	pf.get(3).Complete(30)
+	pf.get(1).Complete(10)
+	receiveInts(t, c, 10, 20, 30)
+
+	sc.Stream().Close()
+	if !ms.Closed().WaitUntilTimeout(10 * time.Millisecond) {
+		t.Error("mapped stream didn't close")
+	}
+}
+
+func TestMapAsyncUnordered(t *testing.T) {
+	pf := newPendingFutures()
+	sc := NewStreamController[int]()
+	ms := MapAsync(sc.Stream(), pf.start, 2, false)
+	c, _ := ms.AsChan()
+	sc.Add(1)
+	sc.Add(2)
+
+	pf.waitStarted(t, 1, 2)
+	pf.get(2).Complete(20)
+	receiveInts(t, c, 20)
+	pf.get(1).Complete(10)
+	receiveInts(t, c, 10)
+}
+
+func TestMapAsyncErrors(t *testing.T) {
+	for _, policy := range []AsyncErrorPolicy{AsyncForwardErrors, AsyncDropErrors} {
+		pf := newPendingFutures()
+		sc := NewStreamController[int]()
+		ms := MapAsyncWithErrorPolicy(sc.Stream(), pf.start, 1, true, policy)
+		errs := make(chan error, 2)
+		ms.OnError(func(err error) {
+			errs <- err
+		})
+		sc.Add(1)
+		pf.waitStarted(t, 1)
+		pf.get(1).CompleteError(ErrTimeout)
+		sc.AddError(ErrEmptyStream)
+
+		want := []error{ErrTimeout, ErrEmptyStream}
+		if policy == AsyncDropErrors {
+			want = want[1:]
+		}
+		for _, w := range want {
+			select {
+			case err := <-errs:
+				if err != w {
+					t.Error("got wrong error", err)
+				}
+			case <-time.After(10 * time.Millisecond):
+				t.Fatal("no error")
+			}
+		}
+	}
+}
+
+func TestMapAsyncCloseWaitsForFutures(t *testing.T) {
+	pf := newPendingFutures()
+	sc := NewStreamController[int]()
+	ms := MapAsync(sc.Stream(), pf.start, 2, true)
+	res := collectStream(ms)
+	sc.Add(1)
+	pf.waitStarted(t, 1)
+	sc.Stream().Close()
+
+	time.Sleep(1 * time.Millisecond)
+	if ms.Closed().Completed() {
+		t.Fatal("mapped stream closed with future in flight")
+	}
+	pf.get(1).Complete(10)
+	checkInts(t, res, []int{10})
+}
+
+func TestMapAsyncTransformerPanic(t *testing.T) {
+	sc := NewStreamController[int]()
+	ms := MapAsync(sc.Stream(), func(d int) *Future[int] {
+		if d == 1 {
+			panic("testpanic")
+		}
+		c := NewCompleter[int]()
+		c.Complete(d * 10)
+		return c.Future()
+	}, 1, true)
+	c, _ := ms.AsChan()
+	errs := make(chan error, 1)
+	ms.OnError(func(err error) {
+		errs <- err
+	})
+
+	sc.Add(1)
+	sc.Add(2)
+	select {
+	case err := <-errs:
+		if perr, ok := err.(*PanicError); !ok || perr.Value != "testpanic" {
+			t.Error("got wrong error", err)
+		}
+	case <-time.After(10 * time.Millisecond):
+		t.Fatal("no error")
+	}
+	receiveInts(t, c, 20)
+}
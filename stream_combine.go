@@ -0,0 +1,272 @@
+package eventual2go
+
+import "sync"
+
+// Pair holds two values of arbitrary types.
+type Pair[A, B any] struct {
+	First  A
+	Second B
+}
+
+// MergeStreams returns a stream, which receives all elements and error events of the given streams. The merged stream
+// gets closed when all sources are closed or with the error of the first source which gets closed with an error.
+func MergeStreams[T any](ss ...*Stream[T]) (ms *Stream[T]) {
+	sc := NewStreamController[T]()
+	ms = sc.Stream()
+	m := &merger[T]{
+		ms:      ms,
+		pending: len(ss),
+	}
+	if len(ss) == 0 {
+		ms.Close()
+		return
+	}
+	for _, s := range ss {
+		s.listenUntil(sc.Add, sc.AddError, ms.Closed(), m.onClose)
+	}
+	return
+}
+
+type merger[T any] struct {
+	m       sync.Mutex
+	ms      *Stream[T]
+	pending int
+}
+
+func (m *merger[T]) onClose(err error) {
+	if err != nil {
+		m.ms.CloseWithError(err)
+		return
+	}
+	m.m.Lock()
+	defer m.m.Unlock()
+	m.pending--
+	if m.pending == 0 {
+		m.ms.Close()
+	}
+}
+
+// ZipStreams returns a stream, which receives pairs of the elements of both sources in the order of their arrival.
+// Elements are buffered until the other source delivers its counterpart. Error events of both sources are forwarded.
+// The zipped stream gets closed when a closed source has no buffered elements left.
+func ZipStreams[A, B any](a *Stream[A], b *Stream[B]) (zs *Stream[Pair[A, B]]) {
+	sc := NewStreamController[Pair[A, B]]()
+	zs = sc.Stream()
+	z := &zipper[A, B]{sc: sc}
+	a.listenUntil(z.addFirst, sc.AddError, zs.Closed(), z.closeFirst)
+	b.listenUntil(z.addSecond, sc.AddError, zs.Closed(), z.closeSecond)
+	return
+}
+
+type zipper[A, B any] struct {
+	m            sync.Mutex
+	sc           *StreamController[Pair[A, B]]
+	first        []A
+	second       []B
+	firstClosed  bool
+	secondClosed bool
+}
+
+func (z *zipper[A, B]) addFirst(d A) {
+	z.m.Lock()
+	defer z.m.Unlock()
+	z.first = append(z.first, d)
+	z.emit()
+}
+
+func (z *zipper[A, B]) addSecond(d B) {
+	z.m.Lock()
+	defer z.m.Unlock()
+	z.second = append(z.second, d)
+	z.emit()
+}
+
+func (z *zipper[A, B]) closeFirst(err error) {
+	if err != nil {
+		z.sc.Stream().CloseWithError(err)
+		return
+	}
+	z.m.Lock()
+	defer z.m.Unlock()
+	z.firstClosed = true
+	z.emit()
+}
+
+func (z *zipper[A, B]) closeSecond(err error) {
+	if err != nil {
+		z.sc.Stream().CloseWithError(err)
+		return
+	}
+	z.m.Lock()
+	defer z.m.Unlock()
+	z.secondClosed = true
+	z.emit()
+}
+
+// must be called with lock held.
+func (z *zipper[A, B]) emit() {
+	for len(z.first) != 0 && len(z.second) != 0 {
+		z.sc.Add(Pair[A, B]{z.first[0], z.second[0]})
+		z.first = z.first[1:]
+		z.second = z.second[1:]
+	}
+	if (z.firstClosed && len(z.first) == 0) || (z.secondClosed && len(z.second) == 0) {
+		z.sc.Stream().Close()
+	}
+}
+
+// CombineLatest returns a stream, which receives a pair of the latest elements of both sources whenever one of them
+// delivers a new element, once both sources have delivered at least one. Error events of both sources are forwarded.
+// The combined stream gets closed when both sources are closed or a source gets closed without delivering an element.
+func CombineLatest[A, B any](a *Stream[A], b *Stream[B]) (cs *Stream[Pair[A, B]]) {
+	sc := NewStreamController[Pair[A, B]]()
+	cs = sc.Stream()
+	c := &combiner[A, B]{sc: sc}
+	a.listenUntil(c.addFirst, sc.AddError, cs.Closed(), c.closeFirst)
+	b.listenUntil(c.addSecond, sc.AddError, cs.Closed(), c.closeSecond)
+	return
+}
+
+type combiner[A, B any] struct {
+	m            sync.Mutex
+	sc           *StreamController[Pair[A, B]]
+	latest       Pair[A, B]
+	hasFirst     bool
+	hasSecond    bool
+	firstClosed  bool
+	secondClosed bool
+}
+
+func (c *combiner[A, B]) addFirst(d A) {
+	c.m.Lock()
+	defer c.m.Unlock()
+	c.latest.First = d
+	c.hasFirst = true
+	c.emit()
+}
+
+func (c *combiner[A, B]) addSecond(d B) {
+	c.m.Lock()
+	defer c.m.Unlock()
+	c.latest.Second = d
+	c.hasSecond = true
+	c.emit()
+}
+
+// must be called with lock held.
+func (c *combiner[A, B]) emit() {
+	if c.hasFirst && c.hasSecond {
+		c.sc.Add(c.latest)
+	}
+}
+
+func (c *combiner[A, B]) closeFirst(err error) {
+	if err != nil {
+		c.sc.Stream().CloseWithError(err)
+		return
+	}
+	c.m.Lock()
+	defer c.m.Unlock()
+	c.firstClosed = true
+	c.close()
+}
+
+func (c *combiner[A, B]) closeSecond(err error) {
+	if err != nil {
+		c.sc.Stream().CloseWithError(err)
+		return
+	}
+	c.m.Lock()
+	defer c.m.Unlock()
+	c.secondClosed = true
+	c.close()
+}
+
+// must be called with lock held.
+func (c *combiner[A, B]) close() {
+	if (c.firstClosed && c.secondClosed) || (c.firstClosed && !c.hasFirst) || (c.secondClosed && !c.hasSecond) {
+		c.sc.Stream().Close()
+	}
+}
+
+// ConcatStreams returns a stream, which receives all elements and error events of the first source until it gets
+// closed, then those of the second source and so on. Elements of a source, which are added before all previous sources
+// are closed, are buffered. The concatenated stream gets closed after the last source is closed or with the error of
+// the current source, if it gets closed with an error.
+func ConcatStreams[T any](ss ...*Stream[T]) (cs *Stream[T]) {
+	sc := NewStreamController[T]()
+	cs = sc.Stream()
+	c := &concatenator[T]{
+		sc:      sc,
+		buffers: make([][]queuedEvent[T], len(ss)),
+		closed:  make([]bool, len(ss)),
+		errs:    make([]error, len(ss)),
+	}
+	for i, s := range ss {
+		s.listenUntil(c.add(i), c.addError(i), cs.Closed(), c.close(i))
+	}
+	c.m.Lock()
+	c.advance()
+	c.m.Unlock()
+	return
+}
+
+type concatenator[T any] struct {
+	m       sync.Mutex
+	sc      *StreamController[T]
+	active  int
+	buffers [][]queuedEvent[T]
+	closed  []bool
+	errs    []error
+}
+
+func (c *concatenator[T]) add(i int) Subscriber[T] {
+	return func(d T) {
+		c.push(i, queuedEvent[T]{data: d})
+	}
+}
+
+func (c *concatenator[T]) addError(i int) ErrorHandler {
+	return func(err error) {
+		c.push(i, queuedEvent[T]{err: err})
+	}
+}
+
+func (c *concatenator[T]) push(i int, evt queuedEvent[T]) {
+	c.m.Lock()
+	defer c.m.Unlock()
+	if i == c.active {
+		c.sc.add(evt.data, evt.err)
+	} else if i > c.active {
+		c.buffers[i] = append(c.buffers[i], evt)
+	}
+}
+
+func (c *concatenator[T]) close(i int) func(error) {
+	return func(err error) {
+		c.m.Lock()
+		defer c.m.Unlock()
+		c.closed[i] = true
+		c.errs[i] = err
+		c.advance()
+	}
+}
+
+// advance moves on to the next source, as long as the active source is closed. Must be called with lock held.
+func (c *concatenator[T]) advance() {
+	for c.active < len(c.closed) {
+		for _, evt := range c.buffers[c.active] {
+			c.sc.add(evt.data, evt.err)
+		}
+		c.buffers[c.active] = nil
+		if !c.closed[c.active] {
+			return
+		}
+		if err := c.errs[c.active]; err != nil {
+			c.sc.Stream().CloseWithError(err)
+			return
+		}
+		c.active++
+	}
+	c.sc.Stream().Close()
+}
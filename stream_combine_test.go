@@ -0,0 +1,158 @@
+package eventual2go
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestMergeStreams(t *testing.T) {
+	sc1 := NewStreamController[int]()
+	sc2 := NewStreamController[int]()
+	ms := MergeStreams(sc1.Stream(), sc2.Stream())
+	c, _ := ms.AsChan()
+
+	sc1.Add(1)
+	if <-c != 1 {
+		t.Error("got wrong data")
+	}
+	sc2.Add(2)
+	if <-c != 2 {
+		t.Error("got wrong data")
+	}
+
+	sc1.Stream().Close()
+	time.Sleep(1 * time.Millisecond)
+	if ms.Closed().Completed() {
+		t.Error("merged stream closed to early")
+	}
+	sc2.Stream().Close()
+	if !ms.Closed().WaitUntilTimeout(10 * time.Millisecond) {
+		t.Error("merged stream didn't close")
+	}
+}
+
+func TestMergeStreamsCloseWithError(t *testing.T) {
+	sc1 := NewStreamController[int]()
+	sc2 := NewStreamController[int]()
+	ms := MergeStreams(sc1.Stream(), sc2.Stream())
+
+	sc2.CloseWithError(errors.New("testerror"))
+
+	if err := <-ms.Closed().AsErrChan(); err == nil || err.Error() != "testerror" {
+		t.Error("got wrong error", err)
+	}
+}
+
+func TestZipStreams(t *testing.T) {
+	sc1 := NewStreamController[int]()
+	sc2 := NewStreamController[string]()
+	zs := ZipStreams(sc1.Stream(), sc2.Stream())
+	c, _ := zs.AsChan()
+
+	sc1.Add(1)
+	sc1.Add(2)
+	sc2.Add("a")
+	sc2.Add("b")
+
+	for _, want := range []Pair[int, string]{{1, "a"}, {2, "b"}} {
+		select {
+		case <-time.After(10 * time.Millisecond):
+			t.Fatal("no response")
+		case data := <-c:
+			if data != want {
+				t.Error("got wrong data", data)
+			}
+		}
+	}
+
+	sc1.Stream().Close()
+	if !zs.Closed().WaitUntilTimeout(10 * time.Millisecond) {
+		t.Error("zipped stream didn't close")
+	}
+}
+
+func TestZipStreamsCloseBuffered(t *testing.T) {
+	sc1 := NewStreamController[int]()
+	sc2 := NewStreamController[string]()
+	zs := ZipStreams(sc1.Stream(), sc2.Stream())
+	c, _ := zs.AsChan()
+
+	sc1.Add(1)
+	time.Sleep(1 * time.Millisecond)
+	sc1.Stream().Close()
+	time.Sleep(1 * time.Millisecond)
+	if zs.Closed().Completed() {
+		t.Fatal("zipped stream closed with buffered elements")
+	}
+
+	sc2.Add("a")
+	if data := <-c; data.First != 1 || data.Second != "a" {
+		t.Error("got wrong data", data)
+	}
+	if !zs.Closed().WaitUntilTimeout(10 * time.Millisecond) {
+		t.Error("zipped stream didn't close")
+	}
+}
+
+func TestCombineLatest(t *testing.T) {
+	sc1 := NewStreamController[int]()
+	sc2 := NewStreamController[string]()
+	cs := CombineLatest(sc1.Stream(), sc2.Stream())
+	c, _ := cs.AsChan()
+
+	sc1.Add(1)
+	time.Sleep(1 * time.Millisecond)
+	sc1.Add(2)
+	time.Sleep(1 * time.Millisecond)
+	sc2.Add("a")
+	if data := <-c; data.First != 2 || data.Second != "a" {
+		t.Error("got wrong data", data)
+	}
+	sc1.Add(3)
+	if data := <-c; data.First != 3 || data.Second != "a" {
+		t.Error("got wrong data", data)
+	}
+
+	sc1.Stream().Close()
+	time.Sleep(1 * time.Millisecond)
+	sc2.Add("b")
+	if data := <-c; data.First != 3 || data.Second != "b" {
+		t.Error("got wrong data", data)
+	}
+	sc2.Stream().Close()
+	if !cs.Closed().WaitUntilTimeout(10 * time.Millisecond) {
+		t.Error("combined stream didn't close")
+	}
+}
+
+func TestConcatStreams(t *testing.T) {
+	sc1 := NewStreamController[int]()
+	sc2 := NewStreamController[int]()
+	cs := ConcatStreams(sc1.Stream(), sc2.Stream())
+	c, _ := cs.AsChan()
+
+	sc2.Add(3)
+	sc1.Add(1)
+	sc1.Add(2)
+	time.Sleep(1 * time.Millisecond)
+	sc1.Stream().Close()
+	time.Sleep(1 * time.Millisecond)
+	sc2.Add(4)
+
+	for i := 1; i <= 4; i++ {
+		select {
+		case <-time.After(10 * time.Millisecond):
+			t.Fatal("no response")
+		case data := <-c:
+			if data != i {
+				t.Error("got wrong data", data)
+			}
+		}
+	}
+
+	sc2.Stream().Close()
+	if !cs.Closed().WaitUntilTimeout(10 * time.Millisecond) {
+		t.Error("concatenated stream didn't close")
+	}
+}
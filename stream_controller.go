@@ -1,54 +1,120 @@
 package eventual2go
 
-import "sync"
+import (
+	"context"
+	"sync"
+)
 
 // A StreamController is Stream where elements can be added manually or other Streams joined in.
 type StreamController[T any] struct {
-	m      *sync.Mutex
-	stream *Stream[T]
-	next   *Completer[*streamEvent[T]]
+	m        *sync.Mutex
+	stream   *Stream[T]
+	next     *Completer[*streamEvent[T]]
+	executor Executor
 }
 
 // NewStreamController creates a new StreamController.
 func NewStreamController[T any]() (sc *StreamController[T]) {
+	return NewStreamControllerWithExecutor[T](nil)
+}
+
+// NewStreamControllerWithExecutor creates a new StreamController, which stream delivers its events to the subscribers
+// with the given Executor instead of new go-routines. Streams derived from the stream use the same Executor. If the
+// Executor is nil, new go-routines are used.
+func NewStreamControllerWithExecutor[T any](e Executor) (sc *StreamController[T]) {
 	sc = &StreamController[T]{
-		m:    &sync.Mutex{},
-		next: NewCompleter[*streamEvent[T]](),
+		m:        &sync.Mutex{},
+		executor: e,
 	}
-	sc.stream = newStream[T](sc.next.Future())
+	sc.next = sc.newEvent()
+	sc.stream = newStream[T](sc.next.Future(), e)
 	return
 }
 
+func (sc *StreamController[T]) newEvent() *Completer[*streamEvent[T]] {
+	if sc.executor == nil {
+		return NewCompleter[*streamEvent[T]]()
+	}
+	return NewCompleterWithExecutor[*streamEvent[T]](sc.executor)
+}
+
 // Add adds an element to the stream.
 func (sc *StreamController[T]) Add(d T) {
+	sc.add(d, nil)
+}
+
+// AddError adds an error event to the stream. Subscribers registered with Listen don't receive error events, use
+// OnError or ListenWithError instead. Adding an error does not close the stream, use CloseWithError to indicate a
+// terminal failure.
+func (sc *StreamController[T]) AddError(err error) {
+	var d T
+	sc.add(d, err)
+}
+
+func (sc *StreamController[T]) add(d T, err error) {
 	sc.m.Lock()
-	defer sc.m.Unlock()
 	next := sc.getNext()
-	next.Complete(&streamEvent[T]{
+	evt := &streamEvent[T]{
 		data: d,
+		err:  err,
 		next: sc.next.Future(),
-	})
+	}
+	sc.m.Unlock()
+	// completing without the lock allows handlers invoked inline, e.g. by an InlineExecutor, to add elements
+	next.Complete(evt)
 }
 
 func (sc *StreamController[T]) getNext() (next *Completer[*streamEvent[T]]) {
 	next = sc.next
-	sc.next = NewCompleter[*streamEvent[T]]()
+	sc.next = sc.newEvent()
 	sc.stream.updateNext(sc.next.Future())
 	return
 }
 
+// addAcked adds an element to the stream and returns a WaitGroup, which is done when all handlers registered at the time
+// of adding have acknowledged it.
+func (sc *StreamController[T]) addAcked(d T, err error) (ack *sync.WaitGroup) {
+	ack = &sync.WaitGroup{}
+	sc.m.Lock()
+	next := sc.next
+	sc.next = sc.newEvent()
+	// holding the stream lock prevents new subscriptions between counting the handlers and completion
+	sc.stream.m.Lock()
+	ack.Add(next.f.handlerCount())
+	next.Complete(&streamEvent[T]{
+		data: d,
+		err:  err,
+		next: sc.next.Future(),
+		ack:  ack,
+	})
+	sc.stream.next = sc.next.Future()
+	sc.stream.m.Unlock()
+	sc.m.Unlock()
+	return
+}
+
 // Stream return the underlying stream.
 func (sc *StreamController[T]) Stream() *Stream[T] {
 	return sc.stream
 }
 
-// Join joins a stream. All elements from the source will be added to the stream
+// Join joins a stream. All elements and error events from the source will be added to the stream
 func (sc *StreamController[T]) Join(source *Stream[T]) {
-	stop := source.Listen(sc.Add)
-	stop.CompleteOnFuture(sc.stream.close.Future())
+	sub := source.ListenWithError(sc.Add, sc.AddError)
+	sub.CancelOnFuture(sc.stream.close.Future())
 }
 
 // JoinFuture joins a future completion event. The result will be added to the stream.
 func (sc *StreamController[T]) JoinFuture(f *Future[T]) {
 	f.Then(sc.Add)
 }
+
+// CloseWithError closes the stream because of a failure.
+func (sc *StreamController[T]) CloseWithError(err error) {
+	sc.stream.CloseWithError(err)
+}
+
+// CloseOnContext closes the stream when the context is done.
+func (sc *StreamController[T]) CloseOnContext(ctx context.Context) {
+	go completeOnContext(sc.stream.close, ctx, true)
+}
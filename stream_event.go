@@ -1,6 +1,18 @@
 package eventual2go
 
+import "sync"
+
 type streamEvent [T any] struct {
 	data T
+	err  error
 	next *Future[*streamEvent[T]]
+	ack  *sync.WaitGroup
+}
+
+// done acknowledges the delivery of the event to a single handler. Only events of a BoundedStreamController are
+// acknowledged.
+func (evt *streamEvent[T]) done() {
+	if evt.ack != nil {
+		evt.ack.Done()
+	}
 }
@@ -0,0 +1,132 @@
+package eventual2go
+
+import "errors"
+
+// ErrEmptyStream represents the error of a stream which got closed without any element being added.
+var ErrEmptyStream = errors.New("Empty stream")
+
+// Take returns a stream, which receives the first n elements of the source stream and gets closed afterwards.
+func (s *Stream[T]) Take(n int) (ts *Stream[T]) {
+	ts = DeriveStream(s, take[T](n))
+	if n <= 0 {
+		ts.Close()
+	}
+	return
+}
+
+func take[T any](n int) DeriveSubscriber[T, T] {
+	taken := 0
+	return func(sc *StreamController[T], d T) {
+		taken++
+		if taken <= n {
+			sc.Add(d)
+		}
+		if taken >= n {
+			sc.Stream().Close()
+		}
+	}
+}
+
+// Skip returns a stream, which receives all elements of the source stream except the first n.
+func (s *Stream[T]) Skip(n int) (ss *Stream[T]) {
+	ss = DeriveStream(s, skip[T](n))
+	return
+}
+
+func skip[T any](n int) DeriveSubscriber[T, T] {
+	skipped := 0
+	return func(sc *StreamController[T], d T) {
+		if skipped < n {
+			skipped++
+			return
+		}
+		sc.Add(d)
+	}
+}
+
+// TakeWhile returns a stream, which receives elements of the source stream as long as the filter returns TRUE. The
+// stream gets closed with the first element where the filter returns FALSE.
+func (s *Stream[T]) TakeWhile(f Filter[T]) (ts *Stream[T]) {
+	ts = DeriveStream(s, takeWhile(f))
+	return
+}
+
+func takeWhile[T any](f Filter[T]) DeriveSubscriber[T, T] {
+	return func(sc *StreamController[T], d T) {
+		if !f(d) {
+			sc.Stream().Close()
+			return
+		}
+		sc.Add(d)
+	}
+}
+
+// SkipWhile returns a stream, which skips elements of the source stream as long as the filter returns TRUE and
+// receives all elements afterwards.
+func (s *Stream[T]) SkipWhile(f Filter[T]) (ss *Stream[T]) {
+	ss = DeriveStream(s, skipWhile(f))
+	return
+}
+
+func skipWhile[T any](f Filter[T]) DeriveSubscriber[T, T] {
+	skipping := true
+	return func(sc *StreamController[T], d T) {
+		if skipping && f(d) {
+			return
+		}
+		skipping = false
+		sc.Add(d)
+	}
+}
+
+// TakeUntil returns a stream, which receives all elements of the source stream until the future completes.
+func (s *Stream[T]) TakeUntil(f *Future[Data]) (ts *Stream[T]) {
+	ts = DeriveStream(s, pass[T])
+	ts.CloseOnFuture(f)
+	return
+}
+
+func pass[T any](sc *StreamController[T], d T) {
+	sc.Add(d)
+}
+
+// Last returns a future that will be completed with the last element added to the stream, after the stream got
+// closed. The future fails with ErrEmptyStream if no element was added, or with the first error event or the error
+// the stream got closed with.
+func (s *Stream[T]) Last() (f *Future[T]) {
+	l := &last[T]{
+		c:    NewCompleter[T](),
+		stop: NewCompleter[Data](),
+	}
+	f = l.c.Future()
+	s.listenUntil(l.add, l.fail, l.stop.Future(), l.close)
+	return
+}
+
+type last[T any] struct {
+	c     *Completer[T]
+	stop  *Completer[Data]
+	value T
+	valid bool
+}
+
+func (l *last[T]) add(d T) {
+	l.value = d
+	l.valid = true
+}
+
+func (l *last[T]) fail(err error) {
+	l.c.TryCompleteError(err)
+	l.stop.TryComplete(nil)
+}
+
+func (l *last[T]) close(err error) {
+	if err == nil && !l.valid {
+		err = ErrEmptyStream
+	}
+	if err != nil {
+		l.c.TryCompleteError(err)
+		return
+	}
+	l.c.TryComplete(l.value)
+}
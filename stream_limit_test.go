@@ -0,0 +1,150 @@
+package eventual2go
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func collectStream[T any](s *Stream[T]) *Future[[]T] {
+	return ReduceStream(s, []T{}, func(a []T, d T) []T { return append(a, d) })
+}
+
+func checkInts(t *testing.T, f *Future[[]int], want []int) {
+	have, err := f.GetTimeout(100 * time.Millisecond)
+	if err != nil {
+		t.Fatal("stream didn't close", err)
+	}
+	if len(have) != len(want) {
+		t.Fatal("got wrong data", have)
+	}
+	for i := range want {
+		if have[i] != want[i] {
+			t.Fatal("got wrong data", have)
+		}
+	}
+}
+
+func TestStreamTake(t *testing.T) {
+	sc := NewStreamController[int]()
+	ts := sc.Stream().Take(2)
+	f := collectStream(ts)
+
+	go func() {
+		for i := 0; i < 5; i++ {
+			sc.Add(i)
+		}
+	}()
+
+	checkInts(t, f, []int{0, 1})
+	if sc.Stream().Closed().Completed() {
+		t.Error("source stream got closed")
+	}
+}
+
+func TestStreamTakeZero(t *testing.T) {
+	sc := NewStreamController[int]()
+	checkInts(t, collectStream(sc.Stream().Take(0)), []int{})
+}
+
+func TestStreamSkip(t *testing.T) {
+	sc := NewStreamController[int]()
+	ss := sc.Stream().Skip(2)
+	f := collectStream(ss)
+
+	go func() {
+		for i := 0; i < 5; i++ {
+			sc.Add(i)
+		}
+		sc.Stream().Close()
+	}()
+
+	checkInts(t, f, []int{2, 3, 4})
+}
+
+func TestStreamTakeWhile(t *testing.T) {
+	sc := NewStreamController[int]()
+	ts := sc.Stream().TakeWhile(func(d int) bool { return d < 3 })
+	f := collectStream(ts)
+
+	go func() {
+		for _, d := range []int{0, 1, 2, 3, 1} {
+			sc.Add(d)
+		}
+	}()
+
+	checkInts(t, f, []int{0, 1, 2})
+}
+
+func TestStreamSkipWhile(t *testing.T) {
+	sc := NewStreamController[int]()
+	ss := sc.Stream().SkipWhile(func(d int) bool { return d < 3 })
+	f := collectStream(ss)
+
+	go func() {
+		for _, d := range []int{0, 1, 2, 3, 1} {
+			sc.Add(d)
+		}
+		sc.Stream().Close()
+	}()
+
+	checkInts(t, f, []int{3, 1})
+}
+
+func TestStreamTakeUntil(t *testing.T) {
+	sc := NewStreamController[int]()
+	stop := NewCompleter[Data]()
+	ts := sc.Stream().TakeUntil(stop.Future())
+	c, _ := ts.AsChan()
+
+	sc.Add(1)
+	if <-c != 1 {
+		t.Error("got wrong data")
+	}
+
+	stop.Complete(nil)
+	if !ts.Closed().WaitUntilTimeout(10 * time.Millisecond) {
+		t.Fatal("stream didn't close")
+	}
+	sc.Add(2)
+	select {
+	case data, ok := <-c:
+		if ok {
+			t.Error("got data after close", data)
+		}
+	case <-time.After(1 * time.Millisecond):
+	}
+}
+
+func TestStreamLast(t *testing.T) {
+	sc := NewStreamController[int]()
+	f := sc.Stream().Last()
+
+	for i := 0; i < 10; i++ {
+		sc.Add(i)
+	}
+	sc.Stream().Close()
+
+	if res, err := f.GetTimeout(10 * time.Millisecond); res != 9 || err != nil {
+		t.Error("Completed with wrong args", res, err)
+	}
+}
+
+func TestStreamLastErr(t *testing.T) {
+	sc := NewStreamController[int]()
+	f := sc.Stream().Last()
+	sc.Stream().Close()
+
+	if _, err := f.GetTimeout(10 * time.Millisecond); err != ErrEmptyStream {
+		t.Error("Completed with wrong err", err)
+	}
+
+	sc = NewStreamController[int]()
+	f = sc.Stream().Last()
+	sc.Add(1)
+	sc.CloseWithError(errors.New("testerror"))
+
+	if _, err := f.GetTimeout(10 * time.Millisecond); err == nil || err.Error() != "testerror" {
+		t.Error("Completed with wrong err", err)
+	}
+}
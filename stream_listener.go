@@ -0,0 +1,152 @@
+package eventual2go
+
+import "sync"
+
+// listenUntil registers a subscriber and an error handler, which get invoked until the given future completes. When the
+// stream gets closed, the closed handler is invoked with the error the stream was closed with, after all elements added
+// before closing were handled.
+func (s *Stream[T]) listenUntil(sr Subscriber[T], eh ErrorHandler, stop *Future[Data], closed func(error)) {
+	s.addListener(&drainingListener[T]{
+		sr:     sr,
+		eh:     eh,
+		stop:   stop,
+		closed: closed,
+		block:  true,
+	})
+}
+
+// addListener starts the delivery of events to the listener. The stream keeps track of the listener until its stop
+// future completes or the stream gets closed, so no handlers pile up on the close future of long-lived streams.
+func (s *Stream[T]) addListener(dl *drainingListener[T]) {
+	dl.s = s
+	s.m.Lock()
+	dl.pos = s.head()
+	if pos := dl.pos; pos.Completed() {
+		s.execute(func() { pos.Then(dl.handle) })
+	} else {
+		dl.pos.Then(dl.handle)
+	}
+	if s.closed {
+		end, err := s.next, s.closeErr
+		s.m.Unlock()
+		dl.close(end, err)
+		return
+	}
+	s.listeners[dl] = struct{}{}
+	s.m.Unlock()
+	dl.stop.Then(s.removeListener(dl))
+	dl.stop.Err(s.removeListenerError(dl))
+}
+
+func (s *Stream[T]) removeListener(dl *drainingListener[T]) CompletionHandler[Data] {
+	return func(Data) {
+		s.m.Lock()
+		defer s.m.Unlock()
+		delete(s.listeners, dl)
+	}
+}
+
+func (s *Stream[T]) removeListenerError(dl *drainingListener[T]) ErrorHandler {
+	return func(error) {
+		s.removeListener(dl)(nil)
+	}
+}
+
+func (s *Stream[T]) onClosed(Data) {
+	s.closeListeners(nil)
+}
+
+func (s *Stream[T]) onClosedError(err error) {
+	s.closeListeners(err)
+}
+
+func (s *Stream[T]) closeListeners(err error) {
+	if s.flush != nil {
+		s.flush()
+	}
+	s.m.Lock()
+	s.closed = true
+	s.closeErr = err
+	end := s.next
+	listeners := s.listeners
+	s.listeners = nil
+	s.m.Unlock()
+	for dl := range listeners {
+		dl.close(end, err)
+	}
+}
+
+type drainingListener[T any] struct {
+	m        sync.Mutex
+	s        *Stream[T]
+	sr       Subscriber[T]
+	eh       ErrorHandler
+	stop     *Future[Data]
+	closed   func(error)
+	block    bool
+	pos      *Future[*streamEvent[T]] // the event the listener is waiting for
+	end      *Future[*streamEvent[T]] // the first event after closing
+	err      error
+	finished bool
+}
+
+func (dl *drainingListener[T]) handle(evt *streamEvent[T]) {
+	defer evt.done()
+	dl.m.Lock()
+	finished := dl.finished
+	dl.m.Unlock()
+	if finished || dl.stop.Completed() {
+		return
+	}
+	if evt.err != nil {
+		if dl.eh != nil {
+			dl.handleError(evt.err)
+		}
+	} else if dl.sr != nil {
+		if dl.block {
+			dl.handleData(evt.data)
+		} else {
+			dl.s.execute(func() { dl.handleData(evt.data) })
+		}
+	}
+	dl.m.Lock()
+	dl.pos = evt.next
+	finish := dl.drained()
+	dl.m.Unlock()
+	if finish {
+		dl.closed(dl.err)
+		return
+	}
+	evt.next.Then(dl.handle)
+}
+
+func (dl *drainingListener[T]) handleData(d T) {
+	defer recoverPanic(dl.s.panicHandler())
+	dl.sr(d)
+}
+
+func (dl *drainingListener[T]) handleError(err error) {
+	defer recoverPanic(dl.s.panicHandler())
+	dl.eh(err)
+}
+
+func (dl *drainingListener[T]) close(end *Future[*streamEvent[T]], err error) {
+	dl.m.Lock()
+	dl.end = end
+	dl.err = err
+	finish := dl.drained()
+	dl.m.Unlock()
+	if finish {
+		dl.closed(err)
+	}
+}
+
+// drained checks if all events before closing were handled and marks the listener as finished. Must be called with
+// lock held.
+func (dl *drainingListener[T]) drained() bool {
+	if dl.finished || dl.end == nil || dl.pos != dl.end {
+		return false
+	}
+	dl.finished = true
+	return true
+}
@@ -0,0 +1,87 @@
+package eventual2go
+
+import "time"
+
+// NewReplayStreamController creates a new StreamController, which stream replays the last n events to new subscribers
+// before delivering new ones. If n is negative, all events are replayed.
+func NewReplayStreamController[T any](n int) (sc *StreamController[T]) {
+	return NewTimedReplayStreamController[T](n, 0, RealClock{})
+}
+
+// NewTimedReplayStreamController is the same as NewReplayStreamController, but only events added within the window,
+// measured with the given Clock, are replayed. A window of 0 means no time limit.
+func NewTimedReplayStreamController[T any](n int, window time.Duration, clock Clock) (sc *StreamController[T]) {
+	sc = NewStreamController[T]()
+	sc.stream.replay = &replayBuffer[T]{
+		size:   n,
+		window: window,
+		clock:  clock,
+	}
+	return
+}
+
+// NewBehaviorStreamController creates a new StreamController, which stream always delivers the latest element to new
+// subscribers, starting with the initial one.
+func NewBehaviorStreamController[T any](initial T) (sc *StreamController[T]) {
+	sc = NewReplayStreamController[T](1)
+	sc.Add(initial)
+	return
+}
+
+type replayBuffer[T any] struct {
+	size   int
+	window time.Duration
+	clock  Clock
+	events []replayedEvent[T]
+}
+
+// deriveReplay returns an empty replayBuffer with the same settings, or nil if rb is nil.
+func deriveReplay[T, V any](rb *replayBuffer[T]) *replayBuffer[V] {
+	if rb == nil {
+		return nil
+	}
+	return &replayBuffer[V]{
+		size:   rb.size,
+		window: rb.window,
+		clock:  rb.clock,
+	}
+}
+
+type replayedEvent[T any] struct {
+	evt   *Future[*streamEvent[T]]
+	added time.Time
+}
+
+func (rb *replayBuffer[T]) retain(evt *Future[*streamEvent[T]]) {
+	rb.events = append(rb.events, replayedEvent[T]{evt, rb.clock.Now()})
+	rb.prune()
+}
+
+// head returns the oldest replayed event, or next if there is none.
+func (rb *replayBuffer[T]) head(next *Future[*streamEvent[T]]) *Future[*streamEvent[T]] {
+	rb.prune()
+	if len(rb.events) == 0 {
+		return next
+	}
+	return rb.events[0].evt
+}
+
+func (rb *replayBuffer[T]) prune() {
+	drop := 0
+	if rb.size >= 0 && len(rb.events) > rb.size {
+		drop = len(rb.events) - rb.size
+	}
+	if rb.window > 0 {
+		oldest := rb.clock.Now().Add(-rb.window)
+		for drop < len(rb.events) && rb.events[drop].added.Before(oldest) {
+			drop++
+		}
+	}
+	if drop == 0 {
+		return
+	}
+	for i := 0; i < drop; i++ {
+		rb.events[i] = replayedEvent[T]{}
+	}
+	rb.events = rb.events[drop:]
+}
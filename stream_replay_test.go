@@ -0,0 +1,126 @@
+package eventual2go
+
+import (
+	"testing"
+	"time"
+)
+
+func receiveInts(t *testing.T, c chan int, want ...int) {
+	for _, w := range want {
+		select {
+		case <-time.After(10 * time.Millisecond):
+			t.Fatal("no response")
+		case data := <-c:
+			if data != w {
+				t.Fatal("got wrong data", data, "want", w)
+			}
+		}
+	}
+	select {
+	case data := <-c:
+		t.Fatal("got unexpected data", data)
+	case <-time.After(1 * time.Millisecond):
+	}
+}
+
+func TestReplayStreamController(t *testing.T) {
+	sc := NewReplayStreamController[int](2)
+	for i := 0; i < 5; i++ {
+		sc.Add(i)
+	}
+
+	c, _ := sc.Stream().AsChan()
+	receiveInts(t, c, 3, 4)
+
+	sc.Add(5)
+	receiveInts(t, c, 5)
+
+	c, _ = sc.Stream().AsChan()
+	receiveInts(t, c, 4, 5)
+}
+
+func TestReplayStreamControllerAll(t *testing.T) {
+	sc := NewReplayStreamController[int](-1)
+	for i := 0; i < 5; i++ {
+		sc.Add(i)
+	}
+
+	c, _ := sc.Stream().AsChan()
+	receiveInts(t, c, 0, 1, 2, 3, 4)
+
+	if d, _ := sc.Stream().First().GetTimeout(10 * time.Millisecond); d != 0 {
+		t.Error("got wrong first element", d)
+	}
+}
+
+func TestTimedReplayStreamController(t *testing.T) {
+	mc := NewManualClock(time.Unix(0, 0))
+	sc := NewTimedReplayStreamController[int](-1, 2*time.Second, mc)
+	sc.Add(0)
+	mc.Advance(1 * time.Second)
+	sc.Add(1)
+	mc.Advance(1500 * time.Millisecond)
+	sc.Add(2)
+
+	c, _ := sc.Stream().AsChan()
+	receiveInts(t, c, 1, 2)
+}
+
+func TestBehaviorStreamController(t *testing.T) {
+	sc := NewBehaviorStreamController[int](42)
+
+	c, _ := sc.Stream().AsChan()
+	receiveInts(t, c, 42)
+
+	sc.Add(1)
+	receiveInts(t, c, 1)
+
+	c, _ = sc.Stream().AsChan()
+	receiveInts(t, c, 1)
+}
+
+func TestReplayStreamDerived(t *testing.T) {
+	sc := NewReplayStreamController[int](-1)
+	sc.Add(1)
+	sc.Add(2)
+	sc.Add(3)
+
+	f := ReduceStream(sc.Stream().Where(func(d int) bool { return d != 2 }), 0, func(a, d int) int { return a + d })
+	sc.Stream().Close()
+
+	if res, err := f.GetTimeout(10 * time.Millisecond); res != 4 || err != nil {
+		t.Error("Completed with wrong args", res, err)
+	}
+}
+
+func TestReplayStreamDerivedLateSubscriber(t *testing.T) {
+	sc := NewReplayStreamController[int](-1)
+	sc.Add(1)
+	sc.Add(2)
+	sc.Add(3)
+
+	ws := sc.Stream().Where(func(d int) bool { return d != 2 })
+	c, _ := ws.AsChan()
+	receiveInts(t, c, 1, 3)
+
+	// all replayed elements are derived already
+	c, _ = ws.AsChan()
+	receiveInts(t, c, 1, 3)
+
+	sc.Add(4)
+	receiveInts(t, c, 4)
+}
+
+func TestReplayStreamDerivedSize(t *testing.T) {
+	sc := NewReplayStreamController[int](1)
+	sc.Add(1)
+
+	ts := TransformStream(sc.Stream(), func(d int) int { return d * 10 })
+	c, _ := ts.AsChan()
+	receiveInts(t, c, 10)
+	sc.Add(2)
+	receiveInts(t, c, 20)
+
+	c, _ = ts.AsChan()
+	receiveInts(t, c, 20)
+}
@@ -0,0 +1,93 @@
+package eventual2go
+
+// ScanStream returns a stream, which receives the accumulated value every time an element is added to the source
+// stream. The accumulation starts with the seed.
+func ScanStream[T, A any](s *Stream[T], seed A, acc Accumulator[T, A]) (ss *Stream[A]) {
+	ss = DeriveStream(s, scan(seed, acc))
+	return
+}
+
+func scan[T, A any](seed A, acc Accumulator[T, A]) DeriveSubscriber[T, A] {
+	value := seed
+	return func(sc *StreamController[A], d T) {
+		value = acc(value, d)
+		sc.Add(value)
+	}
+}
+
+// ReduceStream returns a future, which gets completed with the accumulated value of all elements, after the stream got
+// closed. The accumulation starts with the seed. The future fails with the first error event or the error the stream
+// got closed with.
+func ReduceStream[T, A any](s *Stream[T], seed A, acc Accumulator[T, A]) (f *Future[A]) {
+	r := &reducer[T, A]{
+		c:     NewCompleter[A](),
+		stop:  NewCompleter[Data](),
+		acc:   acc,
+		value: seed,
+	}
+	f = r.c.Future()
+	s.listenUntil(r.add, r.fail, r.stop.Future(), r.close)
+	return
+}
+
+type reducer[T, A any] struct {
+	c     *Completer[A]
+	stop  *Completer[Data]
+	acc   Accumulator[T, A]
+	value A
+}
+
+func (r *reducer[T, A]) add(d T) {
+	r.value = r.acc(r.value, d)
+}
+
+func (r *reducer[T, A]) fail(err error) {
+	r.c.TryCompleteError(err)
+	r.stop.TryComplete(nil)
+}
+
+func (r *reducer[T, A]) close(err error) {
+	if err != nil {
+		r.c.TryCompleteError(err)
+		return
+	}
+	r.c.TryComplete(r.value)
+}
+
+// DistinctUntilChanged returns a stream, which receives only elements which are not equal to their predecessor
+// according to the Comparator.
+func (s *Stream[T]) DistinctUntilChanged(eq Comparator[T]) (ds *Stream[T]) {
+	ds = DeriveStream(s, distinctUntilChanged(eq))
+	return
+}
+
+func distinctUntilChanged[T any](eq Comparator[T]) DeriveSubscriber[T, T] {
+	var last T
+	first := true
+	return func(sc *StreamController[T], d T) {
+		if first || !eq(last, d) {
+			sc.Add(d)
+		}
+		first = false
+		last = d
+	}
+}
+
+// Pairwise returns a stream, which receives every element of the source stream together with its predecessor,
+// starting with the second element.
+func Pairwise[T any](s *Stream[T]) (ps *Stream[Pair[T, T]]) {
+	ps = DeriveStream(s, pairwise[T]())
+	return
+}
+
+func pairwise[T any]() DeriveSubscriber[T, Pair[T, T]] {
+	var last T
+	first := true
+	return func(sc *StreamController[Pair[T, T]], d T) {
+		if !first {
+			sc.Add(Pair[T, T]{last, d})
+		}
+		first = false
+		last = d
+	}
+}
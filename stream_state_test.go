@@ -0,0 +1,104 @@
+package eventual2go
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestScanStream(t *testing.T) {
+	sc := NewStreamController[int]()
+	c, _ := ScanStream(sc.Stream(), "", func(a string, d int) string {
+		return a + string(rune('a'+d))
+	}).AsChan()
+
+	sc.Add(0)
+	sc.Add(1)
+	sc.Add(2)
+
+	for _, want := range []string{"a", "ab", "abc"} {
+		select {
+		case <-time.After(10 * time.Millisecond):
+			t.Fatal("no response")
+		case data := <-c:
+			if data != want {
+				t.Error("got wrong data", data)
+			}
+		}
+	}
+}
+
+func TestReduceStream(t *testing.T) {
+	sc := NewStreamController[int]()
+	f := ReduceStream(sc.Stream(), 0, func(a int, d int) int { return a + d })
+
+	for i := 1; i <= 100; i++ {
+		sc.Add(i)
+	}
+	sc.Stream().Close()
+
+	if res, err := f.GetTimeout(100 * time.Millisecond); res != 5050 || err != nil {
+		t.Error("Completed with wrong args", res, err)
+	}
+}
+
+func TestReduceStreamErr(t *testing.T) {
+	sc := NewStreamController[int]()
+	f := ReduceStream(sc.Stream(), 0, func(a int, d int) int { return a + d })
+
+	sc.Add(1)
+	sc.CloseWithError(errors.New("testerror"))
+
+	if _, err := f.GetTimeout(10 * time.Millisecond); err == nil || err.Error() != "testerror" {
+		t.Error("Completed with wrong err", err)
+	}
+
+	sc = NewStreamController[int]()
+	f = ReduceStream(sc.Stream(), 0, func(a int, d int) int { return a + d })
+
+	sc.AddError(errors.New("testerror"))
+
+	if _, err := f.GetTimeout(10 * time.Millisecond); err == nil || err.Error() != "testerror" {
+		t.Error("Completed with wrong err", err)
+	}
+}
+
+func TestStreamDistinctUntilChanged(t *testing.T) {
+	sc := NewStreamController[int]()
+	c, _ := sc.Stream().DistinctUntilChanged(func(a, b int) bool { return a == b }).AsChan()
+
+	for _, d := range []int{1, 1, 2, 2, 2, 1, 3, 3} {
+		sc.Add(d)
+	}
+
+	for _, want := range []int{1, 2, 1, 3} {
+		select {
+		case <-time.After(10 * time.Millisecond):
+			t.Fatal("no response")
+		case data := <-c:
+			if data != want {
+				t.Error("got wrong data", data)
+			}
+		}
+	}
+}
+
+func TestPairwise(t *testing.T) {
+	sc := NewStreamController[int]()
+	c, _ := Pairwise(sc.Stream()).AsChan()
+
+	sc.Add(1)
+	sc.Add(2)
+	sc.Add(3)
+
+	for _, want := range []Pair[int, int]{{1, 2}, {2, 3}} {
+		select {
+		case <-time.After(10 * time.Millisecond):
+			t.Fatal("no response")
+		case data := <-c:
+			if data != want {
+				t.Error("got wrong data", data)
+			}
+		}
+	}
+}
@@ -1,6 +1,8 @@
 package eventual2go
 
 import (
+	"context"
+	"errors"
 	"sync"
 	"testing"
 	"time"
@@ -24,10 +26,10 @@ func TestStreamBasics(t *testing.T) {
 func TestStreamCancelSub(t *testing.T) {
 	sc := NewStreamController[int]()
 	a := false
-	stop := sc.Stream().Listen(func(d int) {
+	sub := sc.Stream().Listen(func(d int) {
 		a = true
 	})
-	stop.Complete(nil)
+	sub.Cancel()
 	sc.Add(0)
 	if a {
 		t.Error("subscription didn't cancel")
@@ -38,7 +40,7 @@ func TestStreamCancelSub(t *testing.T) {
 	c := sc.Stream().Listen(func(int) {
 		b = true
 	})
-	c.Complete(0)
+	c.Cancel()
 	sc.Add(0)
 
 	m.Lock()
@@ -49,6 +51,56 @@ func TestStreamCancelSub(t *testing.T) {
 
 }
 
+func TestStreamListenContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	sc := NewStreamController[int]()
+	c := make(chan int, 2)
+	sub := sc.Stream().ListenContext(ctx, func(d int) {
+		c <- d
+	})
+	sc.Add(1)
+	if <-c != 1 {
+		t.Error("got wrong data")
+	}
+
+	cancel()
+	if !sub.Done().WaitUntilTimeout(1 * time.Millisecond) {
+		t.Fatal("subscription didn't cancel")
+	}
+	sc.Add(2)
+	time.Sleep(1 * time.Millisecond)
+	if len(c) != 0 {
+		t.Error("subscription didn't cancel")
+	}
+}
+
+func TestStreamListenContextClose(t *testing.T) {
+	sc := NewStreamController[int]()
+	sub := sc.Stream().ListenContext(context.Background(), func(int) {})
+	sc.Stream().Close()
+	if !sub.Done().WaitUntilTimeout(1 * time.Millisecond) {
+		t.Error("subscription didn't cancel")
+	}
+}
+
+func TestStreamControllerCloseOnContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	sc := NewStreamController[int]()
+	sc.CloseOnContext(ctx)
+	w := sc.Stream().Where(func(int) bool { return true })
+
+	cancel()
+	if !sc.Stream().Closed().WaitUntilTimeout(1 * time.Millisecond) {
+		t.Fatal("stream didn't close")
+	}
+	if !w.Closed().WaitUntilTimeout(1 * time.Millisecond) {
+		t.Error("derived stream didn't close")
+	}
+	if sc.Stream().Closed().ErrResult() != nil {
+		t.Error("stream closed with error")
+	}
+}
+
 func TestStreamMultiSubscription(t *testing.T) {
 	sc := NewStreamController[string]()
 	c1, _ := sc.Stream().AsChan()
@@ -157,6 +209,107 @@ func TestStreamTransformer(t *testing.T) {
 	}
 }
 
+func TestStreamError(t *testing.T) {
+	sc := NewStreamController[int]()
+	data, _ := sc.Stream().AsChan()
+	errs := make(chan error, 1)
+	sc.Stream().OnError(func(err error) {
+		errs <- err
+	})
+
+	sc.AddError(errors.New("testerror"))
+	sc.Add(1)
+
+	select {
+	case <-time.After(1 * time.Millisecond):
+		t.Fatal("no response")
+	case err := <-errs:
+		if err.Error() != "testerror" {
+			t.Error("got wrong error")
+		}
+	}
+	select {
+	case <-time.After(1 * time.Millisecond):
+		t.Fatal("no response")
+	case d := <-data:
+		if d != 1 {
+			t.Error("got wrong data")
+		}
+	}
+}
+
+func TestStreamErrorForwarding(t *testing.T) {
+	sc := NewStreamController[int]()
+	ts := TransformStream(sc.Stream().Where(func(int) bool { return false }), func(d int) int { return d })
+	errs := make(chan error, 1)
+	ts.OnError(func(err error) {
+		errs <- err
+	})
+
+	sc.Add(1)
+	sc.AddError(errors.New("testerror"))
+
+	select {
+	case <-time.After(1 * time.Millisecond):
+		t.Fatal("no response")
+	case err := <-errs:
+		if err.Error() != "testerror" {
+			t.Error("got wrong error")
+		}
+	}
+}
+
+func TestStreamCloseWithError(t *testing.T) {
+	sc := NewStreamController[int]()
+	w := sc.Stream().Where(func(int) bool { return true })
+
+	sc.CloseWithError(errors.New("testerror"))
+
+	if err := <-sc.Stream().Closed().AsErrChan(); err == nil || err.Error() != "testerror" {
+		t.Error("got wrong error", err)
+	}
+	if err := <-w.Closed().AsErrChan(); err == nil || err.Error() != "testerror" {
+		t.Error("got wrong error on derived stream", err)
+	}
+
+	sc = NewStreamController[int]()
+	sc.Stream().Close()
+	if err := <-sc.Stream().Closed().AsErrChan(); err != nil {
+		t.Error("got error on normal close", err)
+	}
+}
+
+func TestStreamDeriveCloseAfterDrain(t *testing.T) {
+	sc := NewStreamController[int]()
+	w := sc.Stream().Where(func(int) bool { return true })
+	f := ReduceStream(w, 0, func(a int, d int) int { return a + 1 })
+
+	for i := 0; i < 100; i++ {
+		sc.Add(i)
+	}
+	sc.Stream().Close()
+
+	if res, err := f.GetTimeout(100 * time.Millisecond); res != 100 || err != nil {
+		t.Error("derived stream closed before handling all elements", res, err)
+	}
+}
+
+func TestStreamFirstError(t *testing.T) {
+	sc := NewStreamController[int]()
+	f := sc.Stream().First()
+	fw := sc.Stream().FirstWhere(func(d int) bool { return d == 1 })
+
+	sc.AddError(errors.New("testerror"))
+	sc.Add(1)
+
+	if _, err := f.GetTimeout(10 * time.Millisecond); err == nil || err.Error() != "testerror" {
+		t.Error("got wrong error", err)
+	}
+	if _, err := fw.GetTimeout(10 * time.Millisecond); err == nil || err.Error() != "testerror" {
+		t.Error("got wrong error", err)
+	}
+}
+
 func TestJoinFuture(t *testing.T) {
 
 	sc := NewStreamController[string]()
@@ -0,0 +1,274 @@
+package eventual2go
+
+import (
+	"sync"
+	"time"
+)
+
+// Debounce returns a stream, which receives an element only after the given duration has passed without another element
+// being added to the source stream. An element pending when the stream gets closed is discarded.
+func (s *Stream[T]) Debounce(d time.Duration) (ds *Stream[T]) {
+	return s.DebounceWithClock(d, RealClock{})
+}
+
+// DebounceWithClock is the same as Debounce, but measures the duration with the given Clock.
+func (s *Stream[T]) DebounceWithClock(d time.Duration, clock Clock) (ds *Stream[T]) {
+	db := &debouncer[T]{d: d, clock: clock}
+	ds = DeriveStream(s, db.add)
+	ds.onClose(db.stop)
+	return
+}
+
+type debouncer[T any] struct {
+	m      sync.Mutex
+	d      time.Duration
+	clock  Clock
+	timer  ClockTimer
+	gen    int // identifies the current timer, as stopping doesn't prevent a started fire
+	sc     *StreamController[T]
+	last   T
+	closed bool
+}
+
+func (db *debouncer[T]) add(sc *StreamController[T], d T) {
+	db.m.Lock()
+	defer db.m.Unlock()
+	db.sc = sc
+	db.last = d
+	if db.timer != nil {
+		db.timer.Stop()
+	}
+	db.gen++
+	gen := db.gen
+	db.timer = db.clock.AfterFunc(db.d, func() { db.fire(gen) })
+}
+
+func (db *debouncer[T]) fire(gen int) {
+	db.m.Lock()
+	defer db.m.Unlock()
+	if !db.closed && gen == db.gen {
+		db.sc.Add(db.last)
+	}
+}
+
+func (db *debouncer[T]) stop() {
+	db.m.Lock()
+	defer db.m.Unlock()
+	db.closed = true
+	if db.timer != nil {
+		db.timer.Stop()
+	}
+}
+
+// Throttle returns a stream, which receives at most one element per interval. If leading is TRUE, the first element of
+// an interval is added immediately. If trailing is TRUE, the last element added during an interval is added when it
+// ends.
+func (s *Stream[T]) Throttle(interval time.Duration, leading, trailing bool) (ts *Stream[T]) {
+	return s.ThrottleWithClock(interval, leading, trailing, RealClock{})
+}
+
+// ThrottleWithClock is the same as Throttle, but measures the interval with the given Clock.
+func (s *Stream[T]) ThrottleWithClock(interval time.Duration, leading, trailing bool, clock Clock) (ts *Stream[T]) {
+	th := &throttler[T]{
+		interval: interval,
+		leading:  leading,
+		trailing: trailing,
+		clock:    clock,
+	}
+	ts = DeriveStream(s, th.add)
+	ts.onClose(th.stop)
+	return
+}
+
+type throttler[T any] struct {
+	m        sync.Mutex
+	interval time.Duration
+	leading  bool
+	trailing bool
+	clock    Clock
+	timer    ClockTimer
+	sc       *StreamController[T]
+	pending  bool
+	last     T
+	closed   bool
+}
+
+func (th *throttler[T]) add(sc *StreamController[T], d T) {
+	th.m.Lock()
+	defer th.m.Unlock()
+	th.sc = sc
+	if th.timer == nil {
+		th.timer = th.clock.AfterFunc(th.interval, th.fire)
+		if th.leading {
+			sc.Add(d)
+			return
+		}
+	}
+	if th.trailing {
+		th.last = d
+		th.pending = true
+	}
+}
+
+func (th *throttler[T]) fire() {
+	th.m.Lock()
+	defer th.m.Unlock()
+	if th.closed {
+		return
+	}
+	if !th.pending {
+		th.timer = nil
+		return
+	}
+	th.pending = false
+	th.sc.Add(th.last)
+	th.timer = th.clock.AfterFunc(th.interval, th.fire)
+}
+
+func (th *throttler[T]) stop() {
+	th.m.Lock()
+	defer th.m.Unlock()
+	th.closed = true
+	if th.timer != nil {
+		th.timer.Stop()
+	}
+}
+
+// Sample returns a stream, which receives the latest element of the source stream every interval, if a new element was
+// added to the source stream since the last sample.
+func (s *Stream[T]) Sample(interval time.Duration) (ss *Stream[T]) {
+	return s.SampleWithClock(interval, RealClock{})
+}
+
+// SampleWithClock is the same as Sample, but measures the interval with the given Clock.
+func (s *Stream[T]) SampleWithClock(interval time.Duration, clock Clock) (ss *Stream[T]) {
+	sp := &sampler[T]{interval: interval, clock: clock}
+	ss = DeriveStream(s, sp.add)
+	ss.onClose(sp.stop)
+	return
+}
+
+type sampler[T any] struct {
+	m        sync.Mutex
+	interval time.Duration
+	clock    Clock
+	timer    ClockTimer
+	sc       *StreamController[T]
+	pending  bool
+	last     T
+	closed   bool
+}
+
+func (sp *sampler[T]) add(sc *StreamController[T], d T) {
+	sp.m.Lock()
+	defer sp.m.Unlock()
+	sp.sc = sc
+	sp.last = d
+	sp.pending = true
+	if sp.timer == nil && !sp.closed {
+		sp.timer = sp.clock.AfterFunc(sp.interval, sp.fire)
+	}
+}
+
+func (sp *sampler[T]) fire() {
+	sp.m.Lock()
+	defer sp.m.Unlock()
+	if sp.closed {
+		return
+	}
+	if !sp.pending {
+		sp.timer = nil
+		return
+	}
+	sp.pending = false
+	sp.sc.Add(sp.last)
+	sp.timer = sp.clock.AfterFunc(sp.interval, sp.fire)
+}
+
+func (sp *sampler[T]) stop() {
+	sp.m.Lock()
+	defer sp.m.Unlock()
+	sp.closed = true
+	if sp.timer != nil {
+		sp.timer.Stop()
+	}
+}
+
+// BufferTime returns a stream, which receives the elements of the source stream collected over the given duration.
+// Empty buffers are not added. Elements buffered when the stream gets closed are discarded.
+func BufferTime[T any](s *Stream[T], d time.Duration) (bs *Stream[[]T]) {
+	return BufferTimeWithClock(s, d, RealClock{})
+}
+
+// BufferTimeWithClock is the same as BufferTime, but measures the duration with the given Clock.
+func BufferTimeWithClock[T any](s *Stream[T], d time.Duration, clock Clock) (bs *Stream[[]T]) {
+	b := &timeBuffer[T]{d: d, clock: clock}
+	bs = DeriveStream(s, b.add)
+	bs.onClose(b.stop)
+	return
+}
+
+type timeBuffer[T any] struct {
+	m      sync.Mutex
+	d      time.Duration
+	clock  Clock
+	timer  ClockTimer
+	sc     *StreamController[[]T]
+	buffer []T
+	closed bool
+}
+
+func (b *timeBuffer[T]) add(sc *StreamController[[]T], d T) {
+	b.m.Lock()
+	defer b.m.Unlock()
+	b.sc = sc
+	b.buffer = append(b.buffer, d)
+	if b.timer == nil && !b.closed {
+		b.timer = b.clock.AfterFunc(b.d, b.fire)
+	}
+}
+
+func (b *timeBuffer[T]) fire() {
+	b.m.Lock()
+	defer b.m.Unlock()
+	if b.closed {
+		return
+	}
+	if len(b.buffer) == 0 {
+		b.timer = nil
+		return
+	}
+	b.sc.Add(b.buffer)
+	b.buffer = nil
+	b.timer = b.clock.AfterFunc(b.d, b.fire)
+}
+
+func (b *timeBuffer[T]) stop() {
+	b.m.Lock()
+	defer b.m.Unlock()
+	b.closed = true
+	if b.timer != nil {
+		b.timer.Stop()
+	}
+}
+
+// BufferCount returns a stream, which receives the elements of the source stream in slices of the given size. Elements
+// buffered when the stream gets closed are discarded. A size lower than 1 is treated as 1.
+func BufferCount[T any](s *Stream[T], n int) (bs *Stream[[]T]) {
+	if n < 1 {
+		n = 1
+	}
+	bs = DeriveStream(s, bufferCount[T](n))
+	return
+}
+
+func bufferCount[T any](n int) DeriveSubscriber[T, []T] {
+	buffer := make([]T, 0, n)
+	return func(sc *StreamController[[]T], d T) {
+		buffer = append(buffer, d)
+		if len(buffer) >= n {
+			sc.Add(buffer)
+			buffer = make([]T, 0, n)
+		}
+	}
+}
@@ -0,0 +1,219 @@
+package eventual2go
+
+import (
+	"testing"
+	"time"
+)
+
+// firedClock is a ManualClock, which signals when a function scheduled with AfterFunc returned.
+type firedClock struct {
+	*ManualClock
+	fired chan struct{}
+}
+
+func newFiredClock() firedClock {
+	return firedClock{NewManualClock(time.Unix(0, 0)), make(chan struct{}, 10)}
+}
+
+func (c firedClock) AfterFunc(d time.Duration, f func()) ClockTimer {
+	return c.ManualClock.AfterFunc(d, func() {
+		f()
+		c.fired <- struct{}{}
+	})
+}
+
+// advance advances the clock and waits for the timer expiring at the end.
+func (c firedClock) advance(t *testing.T, d time.Duration) {
+	t.Helper()
+	c.Advance(d)
+	select {
+	case <-c.fired:
+	case <-time.After(10 * time.Millisecond):
+		t.Fatal("timer didn't fire")
+	}
+}
+
+// listenInts collects the elements of the stream. The source controllers use the InlineExecutor, so elements are
+// handled when Add returns.
+func listenInts(s *Stream[int]) (c chan int) {
+	c = make(chan int, 10)
+	s.Listen(func(d int) { c <- d })
+	return
+}
+
+func TestStreamDebounce(t *testing.T) {
+	clock := newFiredClock()
+	sc := NewStreamControllerWithExecutor[int](InlineExecutor{})
+	c := listenInts(sc.Stream().DebounceWithClock(10*time.Millisecond, clock))
+
+	sc.Add(1)
+	clock.Advance(5 * time.Millisecond)
+	sc.Add(2)
+	sc.Add(3)
+	clock.Advance(9 * time.Millisecond)
+	receiveInts(t, c)
+
+	clock.advance(t, time.Millisecond)
+	receiveInts(t, c, 3)
+}
+
+func TestStreamDebounceStaleFire(t *testing.T) {
+	sc := NewStreamController[int]()
+	c, _ := sc.Stream().AsChan()
+	db := &debouncer[int]{d: time.Hour, clock: NewManualClock(time.Unix(0, 0))}
+	defer db.stop()
+
+	db.add(sc, 1)
+	db.add(sc, 2)
+	// a fire of the first timer, which started before it got stopped
+	db.fire(1)
+	select {
+	case data := <-c:
+		t.Fatal("stale timer emitted", data)
+	case <-time.After(1 * time.Millisecond):
+	}
+
+	db.fire(2)
+	select {
+	case <-time.After(10 * time.Millisecond):
+		t.Fatal("no response")
+	case data := <-c:
+		if data != 2 {
+			t.Error("got wrong data", data)
+		}
+	}
+}
+
+func TestStreamThrottle(t *testing.T) {
+	clock := newFiredClock()
+	sc := NewStreamControllerWithExecutor[int](InlineExecutor{})
+	c := listenInts(sc.Stream().ThrottleWithClock(20*time.Millisecond, true, true, clock))
+
+	sc.Add(1)
+	sc.Add(2)
+	sc.Add(3)
+	receiveInts(t, c, 1)
+
+	clock.Advance(19 * time.Millisecond)
+	receiveInts(t, c)
+	clock.advance(t, time.Millisecond)
+	receiveInts(t, c, 3)
+
+	clock.advance(t, 20*time.Millisecond)
+	receiveInts(t, c)
+	if clock.Waiters() != 0 {
+		t.Error("throttle didn't stop its timer")
+	}
+}
+
+func TestStreamThrottleLeading(t *testing.T) {
+	clock := newFiredClock()
+	sc := NewStreamControllerWithExecutor[int](InlineExecutor{})
+	c := listenInts(sc.Stream().ThrottleWithClock(20*time.Millisecond, true, false, clock))
+
+	sc.Add(1)
+	sc.Add(2)
+	receiveInts(t, c, 1)
+
+	clock.advance(t, 20*time.Millisecond)
+	receiveInts(t, c)
+
+	sc.Add(3)
+	receiveInts(t, c, 3)
+}
+
+func TestStreamSample(t *testing.T) {
+	clock := newFiredClock()
+	sc := NewStreamControllerWithExecutor[int](InlineExecutor{})
+	c := listenInts(sc.Stream().SampleWithClock(10*time.Millisecond, clock))
+
+	sc.Add(1)
+	sc.Add(2)
+	clock.Advance(9 * time.Millisecond)
+	receiveInts(t, c)
+	clock.advance(t, time.Millisecond)
+	receiveInts(t, c, 2)
+
+	clock.advance(t, 10*time.Millisecond)
+	receiveInts(t, c)
+}
+
+func TestStreamBufferTime(t *testing.T) {
+	clock := newFiredClock()
+	sc := NewStreamControllerWithExecutor[int](InlineExecutor{})
+	c := make(chan []int, 10)
+	BufferTimeWithClock(sc.Stream(), 10*time.Millisecond, clock).Listen(func(d []int) { c <- d })
+
+	sc.Add(1)
+	sc.Add(2)
+	sc.Add(3)
+	clock.advance(t, 10*time.Millisecond)
+
+	select {
+	case data := <-c:
+		if len(data) != 3 || data[0] != 1 || data[2] != 3 {
+			t.Error("got wrong data", data)
+		}
+	default:
+		t.Fatal("no response")
+	}
+}
+
+func TestStreamBufferCount(t *testing.T) {
+	sc := NewStreamController[int]()
+	c, _ := BufferCount(sc.Stream(), 2).AsChan()
+
+	for i := 0; i < 5; i++ {
+		sc.Add(i)
+	}
+
+	for i := 0; i < 2; i++ {
+		select {
+		case <-time.After(10 * time.Millisecond):
+			t.Fatal("no response")
+		case data := <-c:
+			if len(data) != 2 || data[0] != 2*i || data[1] != 2*i+1 {
+				t.Error("got wrong data", data)
+			}
+		}
+	}
+}
+
+func TestStreamBufferCountZero(t *testing.T) {
+	sc := NewStreamController[int]()
+	c, _ := BufferCount(sc.Stream(), 0).AsChan()
+
+	sc.Add(1)
+	select {
+	case <-time.After(10 * time.Millisecond):
+		t.Fatal("no response")
+	case data := <-c:
+		if len(data) != 1 || data[0] != 1 {
+			t.Error("got wrong data", data)
+		}
+	}
+}
+
+func TestStreamTimeOperatorClose(t *testing.T) {
+	clock := newFiredClock()
+	sc := NewStreamControllerWithExecutor[int](InlineExecutor{})
+	ds := sc.Stream().DebounceWithClock(5*time.Millisecond, clock)
+	c := listenInts(ds)
+
+	sc.Add(1)
+	sc.Stream().Close()
+	if !ds.Closed().WaitUntilTimeout(10 * time.Millisecond) {
+		t.Fatal("derived stream didn't close")
+	}
+	// the timer is stopped by a handler of the closed future
+	timeout := time.After(10 * time.Millisecond)
+	for clock.Waiters() != 0 {
+		select {
+		case <-timeout:
+			t.Fatal("debounce didn't stop its timer")
+		case <-time.After(100 * time.Microsecond):
+		}
+	}
+	clock.Advance(5 * time.Millisecond)
+	receiveInts(t, c)
+}
@@ -0,0 +1,115 @@
+package eventual2go
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// A Subscription represents a subscriber registered on a Stream or an Observable. It can be used to terminate the
+// subscription and to query its state.
+type Subscription struct {
+	m            *sync.Mutex
+	done         *Completer[Data]
+	err          error
+	delivered    uint64
+	lastDelivery time.Time
+}
+
+// SubscriptionStats is a snapshot of the deliveries to a Subscription.
+type SubscriptionStats struct {
+	Delivered    uint64    // number of elements and error events passed to the subscriber
+	LastDelivery time.Time // time of the last delivery, zero if nothing was delivered yet
+}
+
+func newSubscription() (sub *Subscription) {
+	sub = &Subscription{
+		m:    &sync.Mutex{},
+		done: NewCompleter[Data](),
+	}
+	return
+}
+
+// Cancel terminates the subscription. Cancelling an already terminated subscription has no effect.
+func (sub *Subscription) Cancel() {
+	sub.done.TryComplete(nil)
+}
+
+// CancelOnFuture terminates the subscription upon completion of the Future.
+func (sub *Subscription) CancelOnFuture(f *Future[Data]) {
+	f.Then(sub.cancel)
+	f.Err(sub.cancelError)
+}
+
+func (sub *Subscription) cancel(Data) {
+	sub.Cancel()
+}
+
+func (sub *Subscription) cancelError(error) {
+	sub.Cancel()
+}
+
+func (sub *Subscription) cancelOnContext(ctx context.Context) {
+	select {
+	case <-ctx.Done():
+		sub.Cancel()
+	case <-sub.done.Future().AsErrChan():
+	}
+}
+
+// Done returns a Future which completes when the subscription got cancelled or after the subscriber handled all
+// elements added before the Stream got closed.
+func (sub *Subscription) Done() *Future[Data] {
+	return sub.done.Future()
+}
+
+// Err returns the error the Stream was closed with, if the subscription terminated because of CloseWithError.
+func (sub *Subscription) Err() error {
+	sub.m.Lock()
+	defer sub.m.Unlock()
+	return sub.err
+}
+
+// Stats returns a snapshot of the deliveries to the subscriber.
+func (sub *Subscription) Stats() SubscriptionStats {
+	sub.m.Lock()
+	defer sub.m.Unlock()
+	return SubscriptionStats{
+		Delivered:    sub.delivered,
+		LastDelivery: sub.lastDelivery,
+	}
+}
+
+func (sub *Subscription) deliver() {
+	sub.m.Lock()
+	defer sub.m.Unlock()
+	sub.delivered++
+	sub.lastDelivery = time.Now()
+}
+
+func (sub *Subscription) end(err error) {
+	sub.m.Lock()
+	sub.err = err
+	sub.m.Unlock()
+	sub.Cancel()
+}
+
+func deliverTo[T any](sub *Subscription, sr Subscriber[T]) Subscriber[T] {
+	if sr == nil {
+		return nil
+	}
+	return func(d T) {
+		sub.deliver()
+		sr(d)
+	}
+}
+
+func deliverErrorTo(sub *Subscription, eh ErrorHandler) ErrorHandler {
+	if eh == nil {
+		return nil
+	}
+	return func(err error) {
+		sub.deliver()
+		eh(err)
+	}
+}
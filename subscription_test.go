@@ -0,0 +1,100 @@
+package eventual2go
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestSubscriptionCancel(t *testing.T) {
+	sc := NewStreamController[int]()
+	c := make(chan int, 2)
+	sub := sc.Stream().Listen(func(d int) {
+		c <- d
+	})
+
+	sub.Cancel()
+	sub.Cancel()
+	if !sub.Done().WaitUntilTimeout(1 * time.Millisecond) {
+		t.Fatal("subscription didn't cancel")
+	}
+	sc.Add(1)
+	time.Sleep(1 * time.Millisecond)
+	if len(c) != 0 {
+		t.Error("subscription didn't cancel")
+	}
+	if sub.Err() != nil {
+		t.Error("got error", sub.Err())
+	}
+}
+
+func TestSubscriptionStats(t *testing.T) {
+	sc := NewStreamController[int]()
+	c := make(chan struct{}, 3)
+	sub := sc.Stream().ListenWithError(func(int) {
+		c <- struct{}{}
+	}, func(error) {
+		c <- struct{}{}
+	})
+
+	if s := sub.Stats(); s.Delivered != 0 || !s.LastDelivery.IsZero() {
+		t.Error("wrong stats", s)
+	}
+	start := time.Now()
+	sc.Add(1)
+	sc.Add(2)
+	sc.AddError(ErrTimeout)
+	for i := 0; i < 3; i++ {
+		select {
+		case <-c:
+		case <-time.After(10 * time.Millisecond):
+			t.Fatal("no response")
+		}
+	}
+	if s := sub.Stats(); s.Delivered != 3 || s.LastDelivery.Before(start) {
+		t.Error("wrong stats", s)
+	}
+}
+
+func TestSubscriptionDoneOnClose(t *testing.T) {
+	sc := NewStreamController[int]()
+	sub := sc.Stream().Listen(func(int) {})
+
+	sc.CloseWithError(errors.New("testerror"))
+	if !sub.Done().WaitUntilTimeout(10 * time.Millisecond) {
+		t.Fatal("subscription didn't terminate")
+	}
+	if err := sub.Err(); err == nil || err.Error() != "testerror" {
+		t.Error("got wrong error", err)
+	}
+}
+
+func TestSubscriptionCancelOnFuture(t *testing.T) {
+	sc := NewStreamController[int]()
+	sub := sc.Stream().Listen(func(int) {})
+	c := NewCompleter[Data]()
+	sub.CancelOnFuture(c.Future())
+
+	c.CompleteError(ErrTimeout)
+	if !sub.Done().WaitUntilTimeout(1 * time.Millisecond) {
+		t.Error("subscription didn't cancel")
+	}
+}
+
+func TestObservableOnChangeCancel(t *testing.T) {
+	o := NewObservable(0)
+	c := make(chan int, 2)
+	sub := o.OnChange(func(d int) {
+		c <- d
+	})
+	o.Change(1)
+	if <-c != 1 {
+		t.Error("got wrong data")
+	}
+	sub.Cancel()
+	o.Change(2)
+	time.Sleep(1 * time.Millisecond)
+	if len(c) != 0 {
+		t.Error("subscription didn't cancel")
+	}
+}
@@ -0,0 +1,354 @@
+package eventual2go
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+// ErrRestartIntensity is the error a Supervisor fails with, when its children crash more often than allowed.
+var ErrRestartIntensity = errors.New("Restart intensity exceeded")
+
+// ErrNoChild is returned when addressing a child, which doesn't exist or is not running.
+var ErrNoChild = errors.New("No such child")
+
+// ErrSupervisorStopped is returned when starting a child on a stopped Supervisor.
+var ErrSupervisorStopped = errors.New("Supervisor stopped")
+
+// RestartStrategy determines which children a Supervisor restarts when a child crashes.
+type RestartStrategy int
+
+const (
+	// OneForOne restarts only the crashed child.
+	OneForOne RestartStrategy = iota
+	// OneForAll restarts all children.
+	OneForAll
+	// RestForOne restarts the crashed child and all children started after it.
+	RestForOne
+)
+
+// RestartIntensity limits the restarts of a Supervisor. If more than MaxRestarts restarts happen within Period, the
+// supervisor gives up, shuts down all children and fails with ErrRestartIntensity.
+type RestartIntensity struct {
+	MaxRestarts int
+	Period      time.Duration
+}
+
+// Backoff returns the delay before a restart, given the number of restarts within the current period, starting with 1.
+type Backoff func(restarts int) time.Duration
+
+// ExponentialBackoff returns a Backoff, which starts with min and doubles with every restart, but never exceeds max.
+func ExponentialBackoff(min, max time.Duration) Backoff {
+	return func(restarts int) (d time.Duration) {
+		d = min
+		for i := 1; i < restarts && d < max; i++ {
+			d *= 2
+		}
+		if d > max {
+			d = max
+		}
+		return
+	}
+}
+
+// Supervisor spawns child actors and restarts them according to its RestartStrategy when they crash. A child crashes
+// when its OnMessage or Loop method panics or, on restart, when its Init method fails. Supervisors can be nested with
+// StartChildSupervisor to build supervision trees.
+//
+// Supervisor implements Shutdowner, so it can be registered with a Shutdown.
+type Supervisor struct {
+	m         *sync.Mutex
+	strategy  RestartStrategy
+	intensity RestartIntensity
+	backoff   Backoff
+	clock     Clock
+	children  []*supervisedChild
+	restarts  []time.Time
+	stopped   bool
+	done      *Completer[Data]
+}
+
+type supervisedChild struct {
+	name    string
+	start   func(crashed ErrorHandler) (Shutdowner, error)
+	gen     int
+	running Shutdowner
+}
+
+// NewSupervisor creates a new Supervisor. The backoff may be nil, in which case children are restarted immediately.
+func NewSupervisor(strategy RestartStrategy, intensity RestartIntensity, backoff Backoff) (sup *Supervisor) {
+	return NewSupervisorWithClock(strategy, intensity, backoff, RealClock{})
+}
+
+// NewSupervisorWithClock creates a new Supervisor, which uses the given Clock for the restart intensity and backoff.
+func NewSupervisorWithClock(strategy RestartStrategy, intensity RestartIntensity, backoff Backoff, clock Clock) (sup *Supervisor) {
+	sup = &Supervisor{
+		m:         new(sync.Mutex),
+		strategy:  strategy,
+		intensity: intensity,
+		backoff:   backoff,
+		clock:     clock,
+		done:      NewCompleter[Data](),
+	}
+	return
+}
+
+// StartChild spawns an actor created by start as child with the given name. The start function is called again for
+// every restart, so it should return a fresh actor. Returns the error of the actors Init method, in which case the child
+// is not added.
+func (sup *Supervisor) StartChild(name string, start func() Actor) (err error) {
+	return sup.startChild(name, func(crashed ErrorHandler) (Shutdowner, error) {
+		return spawnActor(start(), crashed)
+	})
+}
+
+// StartChildSupervisor adds a nested supervisor created by start as child with the given name. The nested supervisor
+// counts as crashed, when it gives up after exceeding its restart intensity. The start function is called again for
+// every restart and should start the children of the nested supervisor.
+func (sup *Supervisor) StartChildSupervisor(name string, start func() (*Supervisor, error)) (err error) {
+	return sup.startChild(name, func(crashed ErrorHandler) (Shutdowner, error) {
+		child, err := start()
+		if err != nil {
+			return nil, err
+		}
+		// the parent may shut down the child, which can't complete while its done future dispatches
+		child.Done().Err(func(err error) { go crashed(err) })
+		return child, nil
+	})
+}
+
+func (sup *Supervisor) startChild(name string, start func(crashed ErrorHandler) (Shutdowner, error)) (err error) {
+	c := &supervisedChild{
+		name:  name,
+		start: start,
+	}
+
+	sup.m.Lock()
+	if sup.stopped {
+		sup.m.Unlock()
+		return ErrSupervisorStopped
+	}
+	sup.children = append(sup.children, c)
+	sup.m.Unlock()
+
+	running, err := c.start(sup.crashed(c, 0))
+
+	sup.m.Lock()
+	if err != nil {
+		sup.removeChild(c)
+		sup.m.Unlock()
+		return
+	}
+	if c.gen != 0 {
+		// stopped or restarted by a sibling crash while starting
+		stopped := sup.stopped
+		sup.m.Unlock()
+		running.Shutdown(nil)
+		if stopped {
+			err = ErrSupervisorStopped
+		}
+		return
+	}
+	c.running = running
+	sup.m.Unlock()
+	return
+}
+
+// removeChild removes a child, which failed to start. Must be called with lock held.
+func (sup *Supervisor) removeChild(c *supervisedChild) {
+	c.gen++
+	for i, a := range sup.children {
+		if a == c {
+			sup.children = append(sup.children[:i], sup.children[i+1:]...)
+			return
+		}
+	}
+}
+
+// Send sends a message to the current instance of the named child actor. Messages sent while the child is restarting
+// are dropped and ErrNoChild is returned.
+func (sup *Supervisor) Send(name string, data Data) (err error) {
+	sup.m.Lock()
+	defer sup.m.Unlock()
+	for _, c := range sup.children {
+		if c.name != name {
+			continue
+		}
+		if ams, ok := c.running.(ActorMessageStream); ok {
+			ams.Send(data)
+			return
+		}
+	}
+	return ErrNoChild
+}
+
+// Children returns the names of all children in the order they were started.
+func (sup *Supervisor) Children() (names []string) {
+	sup.m.Lock()
+	defer sup.m.Unlock()
+	for _, c := range sup.children {
+		names = append(names, c.name)
+	}
+	return
+}
+
+// Done returns a future, which gets completed when the supervisor is shut down or fails with ErrRestartIntensity, when
+// it gives up.
+func (sup *Supervisor) Done() *Future[Data] {
+	return sup.done.Future()
+}
+
+// Shutdown shuts down all children in the reverse order of their start, invoking the Shutdown method of children
+// implementing ShutdownActor. Returns the first error of the children.
+func (sup *Supervisor) Shutdown(d Data) (err error) {
+	err = sup.stop(d)
+	sup.done.TryComplete(d)
+	return
+}
+
+func (sup *Supervisor) stop(d Data) (err error) {
+	sup.m.Lock()
+	if sup.stopped {
+		sup.m.Unlock()
+		return
+	}
+	sup.stopped = true
+	var running []Shutdowner
+	for _, c := range sup.children {
+		if c.running != nil {
+			running = append(running, c.running)
+		}
+		c.running = nil
+		c.gen++
+	}
+	sup.m.Unlock()
+
+	return shutdownReverse(running, d)
+}
+
+func (sup *Supervisor) giveUp() {
+	sup.stop(ErrRestartIntensity)
+	sup.done.TryCompleteError(ErrRestartIntensity)
+}
+
+func (sup *Supervisor) crashed(c *supervisedChild, gen int) ErrorHandler {
+	return func(error) {
+		sup.m.Lock()
+		if sup.stopped || c.gen != gen {
+			sup.m.Unlock()
+			return
+		}
+		if !sup.recordRestart() {
+			// the crashed child is stopped already
+			c.running = nil
+			sup.m.Unlock()
+			sup.giveUp()
+			return
+		}
+		affected := sup.affected(c)
+		gens := make([]int, len(affected))
+		var running []Shutdowner
+		for i, a := range affected {
+			if a.running != nil && a != c {
+				running = append(running, a.running)
+			}
+			a.running = nil
+			a.gen++
+			gens[i] = a.gen
+		}
+		delay := sup.delay()
+		sup.m.Unlock()
+
+		go sup.restart(affected, gens, running, delay)
+	}
+}
+
+// recordRestart adds a restart and returns false, if the restart intensity is exceeded. Must be called with lock held.
+func (sup *Supervisor) recordRestart() (ok bool) {
+	now := sup.clock.Now()
+	recent := sup.restarts[:0]
+	for _, t := range sup.restarts {
+		if now.Sub(t) < sup.intensity.Period {
+			recent = append(recent, t)
+		}
+	}
+	sup.restarts = append(recent, now)
+	return len(sup.restarts) <= sup.intensity.MaxRestarts
+}
+
+// affected returns the children to restart according to the strategy. Must be called with lock held.
+func (sup *Supervisor) affected(c *supervisedChild) (affected []*supervisedChild) {
+	switch sup.strategy {
+	case OneForAll:
+		return append(affected, sup.children...)
+	case RestForOne:
+		for i, a := range sup.children {
+			if a == c {
+				return append(affected, sup.children[i:]...)
+			}
+		}
+	}
+	return []*supervisedChild{c}
+}
+
+// delay returns the backoff for the current restart. Must be called with lock held.
+func (sup *Supervisor) delay() time.Duration {
+	if sup.backoff == nil {
+		return 0
+	}
+	return sup.backoff(len(sup.restarts))
+}
+
+// restart starts the affected children again after the delay. Children, which gen changed since the crash, are skipped,
+// as they got stopped or are restarted by a later crash.
+func (sup *Supervisor) restart(affected []*supervisedChild, gens []int, running []Shutdowner, delay time.Duration) {
+	shutdownReverse(running, nil)
+
+	if delay > 0 {
+		t := sup.clock.NewTimer(delay)
+		select {
+		case <-t.C():
+		case <-sup.done.Future().AsChan():
+			t.Stop()
+			return
+		}
+	}
+
+	for i, c := range affected {
+		sup.restartChild(c, gens[i])
+	}
+}
+
+func (sup *Supervisor) restartChild(c *supervisedChild, gen int) {
+	sup.m.Lock()
+	skip := sup.stopped || c.gen != gen
+	sup.m.Unlock()
+	if skip {
+		return
+	}
+
+	crashed := sup.crashed(c, gen)
+	running, err := c.start(crashed)
+	if err != nil {
+		crashed(err)
+		return
+	}
+
+	sup.m.Lock()
+	if sup.stopped || c.gen != gen {
+		sup.m.Unlock()
+		running.Shutdown(nil)
+		return
+	}
+	c.running = running
+	sup.m.Unlock()
+}
+
+func shutdownReverse(running []Shutdowner, d Data) (err error) {
+	for i := len(running) - 1; i >= 0; i-- {
+		if serr := running[i].Shutdown(d); serr != nil && err == nil {
+			err = serr
+		}
+	}
+	return
+}
@@ -0,0 +1,305 @@
+package eventual2go
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+type supervisedTestActor struct {
+	name    string
+	events  chan string
+	initErr error
+}
+
+func (a *supervisedTestActor) Init() error {
+	a.events <- a.name + " init"
+	return a.initErr
+}
+
+func (a *supervisedTestActor) OnMessage(d Data) {
+	if d == "panic" {
+		panic("boom")
+	}
+	a.events <- a.name + " " + d.(string)
+}
+
+func (a *supervisedTestActor) Shutdown(Data) error {
+	a.events <- a.name + " shutdown"
+	return nil
+}
+
+func startTestChildren(t *testing.T, sup *Supervisor, events chan string, names ...string) {
+	t.Helper()
+	for _, name := range names {
+		name := name
+		if err := sup.StartChild(name, func() Actor { return &supervisedTestActor{name: name, events: events} }); err != nil {
+			t.Fatal(err)
+		}
+		receiveStrings(t, events, name+" init")
+	}
+}
+
+// sendRunning sends to a child, waiting for it to be running after a restart.
+func sendRunning(t *testing.T, sup *Supervisor, name string, d Data) {
+	t.Helper()
+	timeout := time.After(10 * time.Millisecond)
+	for sup.Send(name, d) == ErrNoChild {
+		select {
+		case <-timeout:
+			t.Fatal("child not running")
+		case <-time.After(100 * time.Microsecond):
+		}
+	}
+}
+
+func TestSupervisorOneForOne(t *testing.T) {
+	events := make(chan string, 10)
+	sup := NewSupervisor(OneForOne, RestartIntensity{MaxRestarts: 3, Period: time.Hour}, nil)
+	startTestChildren(t, sup, events, "a", "b")
+
+	sup.Send("a", "panic")
+	receiveStrings(t, events, "a init")
+
+	sendRunning(t, sup, "a", "x")
+	receiveStrings(t, events, "a x")
+	sup.Send("b", "y")
+	receiveStrings(t, events, "b y")
+}
+
+func TestSupervisorOneForAll(t *testing.T) {
+	events := make(chan string, 10)
+	sup := NewSupervisor(OneForAll, RestartIntensity{MaxRestarts: 3, Period: time.Hour}, nil)
+	startTestChildren(t, sup, events, "a", "b", "c")
+
+	sup.Send("b", "panic")
+	receiveStrings(t, events, "c shutdown", "a shutdown", "a init", "b init", "c init")
+}
+
+func TestSupervisorRestForOne(t *testing.T) {
+	events := make(chan string, 10)
+	sup := NewSupervisor(RestForOne, RestartIntensity{MaxRestarts: 3, Period: time.Hour}, nil)
+	startTestChildren(t, sup, events, "a", "b", "c")
+
+	sup.Send("b", "panic")
+	receiveStrings(t, events, "c shutdown", "b init", "c init")
+}
+
+func TestSupervisorRestartIntensity(t *testing.T) {
+	events := make(chan string, 10)
+	sup := NewSupervisor(OneForOne, RestartIntensity{MaxRestarts: 1, Period: time.Hour}, nil)
+	startTestChildren(t, sup, events, "a", "b")
+
+	sup.Send("a", "panic")
+	receiveStrings(t, events, "a init")
+	sendRunning(t, sup, "a", "panic")
+	receiveStrings(t, events, "b shutdown")
+
+	if _, err := sup.Done().GetTimeout(10 * time.Millisecond); err != ErrRestartIntensity {
+		t.Error("wrong error", err)
+	}
+	if err := sup.Send("b", "x"); err != ErrNoChild {
+		t.Error("wrong error", err)
+	}
+}
+
+func TestSupervisorRestartIntensityPeriod(t *testing.T) {
+	events := make(chan string, 10)
+	clock := NewManualClock(time.Unix(0, 0))
+	sup := NewSupervisorWithClock(OneForOne, RestartIntensity{MaxRestarts: 1, Period: time.Second}, nil, clock)
+	startTestChildren(t, sup, events, "a")
+
+	sup.Send("a", "panic")
+	receiveStrings(t, events, "a init")
+	clock.Advance(time.Second)
+	sendRunning(t, sup, "a", "panic")
+	receiveStrings(t, events, "a init")
+
+	if sup.Done().Completed() {
+		t.Error("supervisor gave up")
+	}
+}
+
+func TestSupervisorBackoff(t *testing.T) {
+	events := make(chan string, 10)
+	clock := NewManualClock(time.Unix(0, 0))
+	sup := NewSupervisorWithClock(OneForOne, RestartIntensity{MaxRestarts: 3, Period: time.Hour}, ExponentialBackoff(time.Second, 3*time.Second), clock)
+	startTestChildren(t, sup, events, "a")
+
+	for _, delay := range []time.Duration{time.Second, 2 * time.Second, 3 * time.Second} {
+		sendRunning(t, sup, "a", "panic")
+		clock.BlockUntil(1)
+		clock.Advance(delay - time.Millisecond)
+		receiveStrings(t, events)
+		clock.Advance(time.Millisecond)
+		receiveStrings(t, events, "a init")
+	}
+}
+
+func TestExponentialBackoff(t *testing.T) {
+	b := ExponentialBackoff(time.Second, 5*time.Second)
+	for i, want := range []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second} {
+		if d := b(i + 1); d != want {
+			t.Error("wrong backoff", i+1, d)
+		}
+	}
+}
+
+func TestSupervisorStartChildInitError(t *testing.T) {
+	events := make(chan string, 10)
+	sup := NewSupervisor(OneForOne, RestartIntensity{MaxRestarts: 3, Period: time.Hour}, nil)
+	err := sup.StartChild("a", func() Actor { return &supervisedTestActor{name: "a", events: events, initErr: errors.New("testerror")} })
+	if err == nil || err.Error() != "testerror" {
+		t.Error("wrong error", err)
+	}
+	if len(sup.Children()) != 0 {
+		t.Error("child got added", sup.Children())
+	}
+}
+
+func TestSupervisorRestartInitError(t *testing.T) {
+	events := make(chan string, 10)
+	sup := NewSupervisor(OneForOne, RestartIntensity{MaxRestarts: 2, Period: time.Hour}, nil)
+	starts := 0
+	err := sup.StartChild("a", func() Actor {
+		a := &supervisedTestActor{name: "a", events: events}
+		if starts > 0 {
+			a.initErr = errors.New("testerror")
+		}
+		starts++
+		return a
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	receiveStrings(t, events, "a init")
+
+	sup.Send("a", "panic")
+	receiveStrings(t, events, "a init", "a init")
+	if _, err := sup.Done().GetTimeout(10 * time.Millisecond); err != ErrRestartIntensity {
+		t.Error("wrong error", err)
+	}
+}
+
+func TestSupervisorOneForAllRestartInitError(t *testing.T) {
+	events := make(chan string, 20)
+	sup := NewSupervisor(OneForAll, RestartIntensity{MaxRestarts: 3, Period: time.Hour}, nil)
+	startTestChildren(t, sup, events, "a")
+	starts := 0
+	err := sup.StartChild("b", func() Actor {
+		a := &supervisedTestActor{name: "b", events: events}
+		if starts == 1 {
+			a.initErr = errors.New("testerror")
+		}
+		starts++
+		return a
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	receiveStrings(t, events, "b init")
+	startTestChildren(t, sup, events, "c")
+
+	sup.Send("b", "panic")
+	receiveStrings(t, events, "c shutdown", "a shutdown", "a init", "b init", "a shutdown", "a init", "b init", "c init")
+
+	sendRunning(t, sup, "c", "x")
+	receiveStrings(t, events, "c x")
+	sup.Shutdown(nil)
+	receiveStrings(t, events, "c shutdown", "b shutdown", "a shutdown")
+}
+
+func TestSupervisorShutdown(t *testing.T) {
+	events := make(chan string, 10)
+	sup := NewSupervisor(OneForOne, RestartIntensity{MaxRestarts: 3, Period: time.Hour}, nil)
+	startTestChildren(t, sup, events, "a", "b")
+
+	sd := NewShutdown()
+	sd.Register(sup)
+	if errs := sd.Do(nil); len(errs) != 0 {
+		t.Error("got errors", errs)
+	}
+	receiveStrings(t, events, "b shutdown", "a shutdown")
+
+	if !sup.Done().Completed() {
+		t.Error("supervisor didn't complete")
+	}
+	if err := sup.StartChild("c", func() Actor { return &supervisedTestActor{name: "c", events: events} }); err != ErrSupervisorStopped {
+		t.Error("wrong error", err)
+	}
+}
+
+func TestSupervisorTree(t *testing.T) {
+	events := make(chan string, 10)
+	sup := NewSupervisor(OneForOne, RestartIntensity{MaxRestarts: 3, Period: time.Hour}, nil)
+	var child *Supervisor
+	err := sup.StartChildSupervisor("child", func() (*Supervisor, error) {
+		child = NewSupervisor(OneForOne, RestartIntensity{MaxRestarts: 0, Period: time.Hour}, nil)
+		return child, child.StartChild("a", func() Actor { return &supervisedTestActor{name: "a", events: events} })
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	receiveStrings(t, events, "a init")
+
+	child.Send("a", "panic")
+	receiveStrings(t, events, "a init")
+
+	if sup.Done().Completed() {
+		t.Error("supervisor gave up")
+	}
+	sup.Shutdown(nil)
+	receiveStrings(t, events, "a shutdown")
+}
+
+func TestSupervisorTreeEscalation(t *testing.T) {
+	events := make(chan string, 10)
+	sup := NewSupervisor(OneForOne, RestartIntensity{MaxRestarts: 0, Period: time.Hour}, nil)
+	var child *Supervisor
+	err := sup.StartChildSupervisor("child", func() (*Supervisor, error) {
+		child = NewSupervisor(OneForOne, RestartIntensity{MaxRestarts: 0, Period: time.Hour}, nil)
+		return child, child.StartChild("a", func() Actor { return &supervisedTestActor{name: "a", events: events} })
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	receiveStrings(t, events, "a init")
+
+	child.Send("a", "panic")
+	if _, err := child.Done().GetTimeout(10 * time.Millisecond); err != ErrRestartIntensity {
+		t.Error("wrong child error", err)
+	}
+	if _, err := sup.Done().GetTimeout(10 * time.Millisecond); err != ErrRestartIntensity {
+		t.Error("wrong error", err)
+	}
+	receiveStrings(t, events)
+}
+
+func TestSpawnActorCrashIgnoresMessages(t *testing.T) {
+	events := make(chan string, 10)
+	crashed := make(chan error, 1)
+	ams, err := spawnActor(&supervisedTestActor{name: "a", events: events}, func(err error) { crashed <- err })
+	if err != nil {
+		t.Fatal(err)
+	}
+	receiveStrings(t, events, "a init")
+
+	ams.Send("panic")
+	ams.Send("x")
+	select {
+	case err := <-crashed:
+		if perr, ok := err.(*PanicError); !ok || perr.Value != "boom" {
+			t.Error("wrong error", err)
+		}
+	case <-time.After(10 * time.Millisecond):
+		t.Fatal("no crash")
+	}
+	receiveStrings(t, events)
+
+	if _, ok := ams.Shutdown(nil).(*PanicError); !ok {
+		t.Error("shutdown didn't return the panic")
+	}
+	if !ams.streamController.Stream().Closed().WaitUntilTimeout(10 * time.Millisecond) {
+		t.Error("message stream didn't close")
+	}
+}